@@ -8,10 +8,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
+	"io/fs"
+	"maps"
 	"path/filepath"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/fsgo/fsenv"
 )
@@ -20,13 +22,18 @@ import (
 // 返回的实例是没有注册任何解析能力的
 func New() *Configure {
 	return &Configure{
-		parsers: map[string]DecoderFunc{},
+		parsers:         map[string]DecoderFunc{},
+		encoders:        map[string]EncoderFunc{},
+		secretProviders: map[string]SecretProvider{},
+		secrets:         newSecretStore(),
 	}
 }
 
 // NewDefault 创建一个新的配置解析实例
-// 会注册默认的配置解析方法和辅助方法
-func NewDefault() *Configure {
+// 会注册默认的配置解析方法和辅助方法，可以使用 opts 选择其中的一部分，如：
+//
+//	NewDefault(ExcludeHooks("fsenv"))
+func NewDefault(opts ...DefaultOption) *Configure {
 	conf := New()
 	for _, pair := range defaultParsers {
 		if err := conf.RegisterParser(pair.Name, pair.Fn); err != nil {
@@ -34,23 +41,127 @@ func NewDefault() *Configure {
 		}
 	}
 
-	for _, h := range defaultHooks {
-		if err := conf.RegisterHook(h); err != nil {
-			panic(fmt.Sprintf("RegisterInterceptor(%q) err=%s", h.Name(), err))
+	// defaultHooks 已按照优先级排好序，直接按序添加，以保留 RegisterHookBefore 等指定的位置
+	for _, e := range defaultHooks {
+		if err := conf.hooks.check(e.Hook); err != nil {
+			panic(fmt.Sprintf("RegisterHook(%q) err=%s", e.Name(), err))
 		}
+		conf.hooks = append(conf.hooks, e)
+	}
+
+	for ext, fn := range defaultEncoders {
+		if err := conf.RegisterEncoder(ext, fn); err != nil {
+			panic(fmt.Sprintf("RegisterEncoder(%q) err=%s", ext, err))
+		}
+	}
+
+	for _, sp := range defaultSecretProviders {
+		if err := conf.RegisterSecretProvider(sp); err != nil {
+			panic(fmt.Sprintf("RegisterSecretProvider(%q) err=%s", sp.Name(), err))
+		}
+	}
+
+	for _, opt := range opts {
+		opt(conf)
 	}
 	return conf
 }
 
+// DefaultOption NewDefault 的选项
+type DefaultOption func(c *Configure)
+
+// OnlyHooks 只保留指定名称的默认 Hook
+func OnlyHooks(names ...string) DefaultOption {
+	return func(c *Configure) {
+		for _, h := range c.Hooks() {
+			if !slices.Contains(names, h.Name()) {
+				c.RemoveHook(h.Name())
+			}
+		}
+	}
+}
+
+// ExcludeHooks 不注册指定名称的默认 Hook
+func ExcludeHooks(names ...string) DefaultOption {
+	return func(c *Configure) {
+		for _, name := range names {
+			c.RemoveHook(name)
+		}
+	}
+}
+
+// OnlyParsers 只保留指定文件后缀的默认 parser
+func OnlyParsers(fileExts ...string) DefaultOption {
+	return func(c *Configure) {
+		for _, ext := range c.Parsers() {
+			if !slices.Contains(fileExts, ext) {
+				c.RemoveParser(ext)
+			}
+		}
+	}
+}
+
+// ExcludeParsers 不注册指定文件后缀的默认 parser
+func ExcludeParsers(fileExts ...string) DefaultOption {
+	return func(c *Configure) {
+		for _, ext := range fileExts {
+			c.RemoveParser(ext)
+		}
+	}
+}
+
 type Configure struct {
 	ctx        context.Context
 	validate   Validator
 	parsers    map[string]DecoderFunc
 	parseNames []string // 支持的文件后缀，如 []string{".json",".toml"}
-	hooks      hooks
+	extOrder   []string // 查找配置文件时，优先使用的后缀
+	strictExt  bool     // 配置文件名不包含后缀且匹配到多个文件时，是否返回错误
+
+	// searchPaths 查找配置文件的目录，为空时使用 fsenv.ConfDir() 和当前目录
+	searchPaths []string
+	searchMode  SearchMode
+	hooks       hooks
+	encoders    map[string]EncoderFunc
+
+	// profileOverlay 是否自动合并 RunMode 和 IDC 对应的配置文件
+	profileOverlay bool
+
+	envOverride *EnvOverride
+
+	redact *Redactor
+
+	secretProviders map[string]SecretProvider
+	secretTTL       time.Duration
+	secrets         *secretStore
+
+	keyProvider KeyProvider
+
+	// envFiles osenv Hook 额外读取变量的 .env 文件
+	envFiles []string
+
+	// env 应用的环境信息，为 nil 时使用全局的 fsenv.Default
+	env *fsenv.Attribute
+
+	// fsys 读取配置文件使用的文件系统，为 nil 时使用本地文件系统
+	fsys fs.FS
+
+	// tplFuncs 使用 RegisterTemplateFunc 注册的 template hook 函数
+	tplFuncs map[string]any
 }
 
 func (c *Configure) Parse(confName string, obj any) (err error) {
+	if c.searchMode == SearchMergeAll {
+		if cands := c.searchCandidates(confName); len(cands) > 1 {
+			files, err := c.locateFiles(cands)
+			if err != nil {
+				return c.redactError(err)
+			}
+			if len(files) > 1 {
+				return c.redactError(c.parseMerged(files, obj))
+			}
+		}
+	}
 	confAbsPath, err := c.confFileAbsPath(confName)
 	if err != nil {
 		return err
@@ -59,6 +170,12 @@ func (c *Configure) Parse(confName string, obj any) (err error) {
 }
 
 func (c *Configure) confFileAbsPath(confName string) (string, error) {
+	if cands := c.searchCandidates(confName); len(cands) > 0 {
+		return c.searchFileAbsPath(cands)
+	}
+	if c.fsys != nil {
+		return c.fsPath(confName)
+	}
 	if strings.HasPrefix(confName, "./") {
 		return filepath.Abs(confName)
 	}
@@ -69,49 +186,43 @@ func (c *Configure) confFileAbsPath(confName string) (string, error) {
 		return confName, nil
 	}
 
-	fp := filepath.Join(fsenv.ConfDir(), confName)
+	fp := filepath.Join(c.appEnv().ConfDir(), confName)
 
-	if !fileExists(fp) {
-		if fp1, err := filepath.Abs(confName); err == nil && fileExists(fp1) {
+	if !c.fileExists(fp) {
+		if fp1, err := filepath.Abs(confName); err == nil && c.fileExists(fp1) {
 			return fp1, nil
 		}
 	}
 	return fp, nil
 }
 
-func fileExists(fp string) bool {
-	info, err := os.Stat(fp)
-	return err == nil && !info.IsDir()
-}
-
 func (c *Configure) ParseByAbsPath(confAbsPath string, obj any) (err error) {
 	if len(c.parsers) == 0 {
 		return errors.New("no parser")
 	}
 
-	return c.readConfDirect(confAbsPath, obj)
+	return c.redactError(c.readConfDirect(confAbsPath, obj))
 }
 
 func (c *Configure) realConfPath(confPath string) (path string, ext string, err error) {
 	fileExt := filepath.Ext(confPath)
-	info, err1 := os.Stat(confPath)
+	info, err1 := c.statFile(confPath)
 
 	if err1 == nil && !info.IsDir() {
 		return confPath, fileExt, nil
 	}
 
-	notExist := err1 != nil && os.IsNotExist(err1)
+	notExist := err1 != nil && errors.Is(err1, fs.ErrNotExist)
 	isDir := err1 == nil && info.IsDir()
 
 	// fileExt == "" 是为了兼容存在同名目录的情况
 	if (notExist || isDir || fileExt == "") && !slices.Contains(c.parseNames, fileExt) {
-		for i := 0; i < len(c.parseNames); i++ {
-			ext2 := c.parseNames[i]
-			name2 := confPath + ext2
-			info2, err2 := os.Stat(name2)
-			if err2 == nil && !info2.IsDir() {
-				return name2, ext2, nil
-			}
+		name2, ext2, found, err2 := c.probeExt(confPath)
+		if err2 != nil {
+			return "", "", err2
+		}
+		if found {
+			return name2, ext2, nil
 		}
 	}
 	if err1 != nil {
@@ -121,13 +232,17 @@ func (c *Configure) realConfPath(confPath string) (path string, ext string, err
 }
 
 func (c *Configure) readConfDirect(confPath string, obj any) error {
-	realFile, fileExt, err := c.realConfPath(confPath)
+	if c.dropInDir(confPath) {
+		return c.parseMerged([]string{confPath}, obj)
+	}
+	realFile, fileExt, content, err := c.readConfFile(confPath)
 	if err != nil {
 		return err
 	}
-	content, errIO := os.ReadFile(realFile)
-	if errIO != nil {
-		return errIO
+	if c.profileOverlay {
+		if overlays := c.profileFiles(realFile, fileExt); len(overlays) > 0 {
+			return c.parseWithOverlays(realFile, fileExt, content, overlays, obj)
+		}
 	}
 	err2 := c.parseBytes(realFile, fileExt, content, obj)
 	if err2 == nil {
@@ -136,6 +251,20 @@ func (c *Configure) readConfDirect(confPath string, obj any) error {
 	return fmt.Errorf("parser %q failed: %w", realFile, err2)
 }
 
+// readConfFile 读取配置文件的内容，confPath 的文件后缀是可选的
+func (c *Configure) readConfFile(confPath string) (realFile string, fileExt string, content []byte, err error) {
+	realFile, fileExt, err = c.realConfPath(confPath)
+	if err != nil {
+		return "", "", nil, err
+	}
+	trackFile(c.context(), realFile)
+	content, err = c.readFile(realFile)
+	if err != nil {
+		return "", "", nil, err
+	}
+	return realFile, fileExt, content, nil
+}
+
 func (c *Configure) context() context.Context {
 	if c.ctx == nil {
 		return context.Background()
@@ -144,10 +273,18 @@ func (c *Configure) context() context.Context {
 }
 
 func (c *Configure) ParseBytes(fileExt string, content []byte, obj any) error {
-	return c.parseBytes("", fileExt, content, obj)
+	return c.redactError(c.parseBytes("", fileExt, content, obj))
 }
 
 func (c *Configure) parseBytes(confPath string, fileExt string, content []byte, obj any) error {
+	if err := c.decodeBytes(confPath, fileExt, content, obj); err != nil {
+		return err
+	}
+	return c.afterDecode(obj)
+}
+
+// decodeBytes 执行所有的 Hook，并使用 fileExt 对应的 parser 解析内容
+func (c *Configure) decodeBytes(confPath string, fileExt string, content []byte, obj any) error {
 	parserFn, hasParser := c.parsers[fileExt]
 	if len(fileExt) == 0 || !hasParser {
 		err1 := fmt.Errorf("fileExt %q is not supported yet", fileExt)
@@ -164,14 +301,50 @@ func (c *Configure) parseBytes(confPath string, fileExt string, content []byte,
 		Content:   content,
 	}
 
-	contentNew, errHook := c.hooks.Execute(c.context(), p)
+	// 记录 Hook 读取的文件（如 include），以便于解析失败时定位出错的位置
+	ft := &fileTracker{parent: fileTrackerFromContext(c.context())}
+	ctx := withFileTracker(c.context(), ft)
+
+	rec := traceRecorderFromContext(ctx)
+	var stages *hookStages
+	if rec != nil {
+		stages = &hookStages{}
+		ctx = withHookStages(ctx, stages)
+	}
+
+	contentNew, errHook := c.hooks.Execute(ctx, p)
 
 	if errHook != nil {
 		return errHook
 	}
 
 	if errParser := parserFn(contentNew, obj); errParser != nil {
-		return fmt.Errorf("%w, config content=\n%s", errParser, string(contentNew))
+		return c.newParseError(confPath, content, contentNew, ft.fileList(), errParser)
+	}
+
+	if rec != nil {
+		rec.add(&fileTrace{
+			path:     confPath,
+			fileExt:  fileExt,
+			rendered: contentNew,
+			stages:   stages.list(),
+			includes: ft.fileList(),
+		})
+	}
+	return nil
+}
+
+// afterDecode 在配置内容解析到 obj 后执行，如设置默认值、校验等
+func (c *Configure) afterDecode(obj any) error {
+	rec := traceRecorderFromContext(c.context())
+	if err := applyDefaults(obj, rec); err != nil {
+		return err
+	}
+
+	if c.envOverride != nil {
+		if err := c.envOverride.apply(obj, c.redactor(), rec); err != nil {
+			return err
+		}
 	}
 
 	if vd := c.getValidator(); vd != nil {
@@ -195,26 +368,20 @@ func (c *Configure) getValidator() Validator {
 	return DefaultValidator
 }
 
+// Exists 判断 confName 对应的配置文件（或者 conf.d 目录）是否存在，
+// 若需要知道实际使用的是哪个文件（如设置了多个查找目录时），请使用 Locate
 func (c *Configure) Exists(confName string) bool {
 	p, err := c.confFileAbsPath(confName)
 	if err != nil {
 		return false
 	}
 
-	info, err := os.Stat(p)
-	if err == nil && !info.IsDir() {
-		return true
-	}
-	if !os.IsNotExist(err) {
-		return false
-	}
-	for ext := range c.parsers {
-		info1, err1 := os.Stat(p + ext)
-		if err1 == nil && !info1.IsDir() {
-			return true
-		}
+	if c.dropInDir(p) {
+		_, err = c.dirFiles(p)
+		return err == nil
 	}
-	return false
+	_, _, err = c.realConfPath(p)
+	return err == nil
 }
 
 func (c *Configure) RegisterParser(fileExt string, fn DecoderFunc) error {
@@ -226,11 +393,81 @@ func (c *Configure) RegisterParser(fileExt string, fn DecoderFunc) error {
 	return nil
 }
 
+// Parsers 返回所有已注册 parser 的文件后缀，按照注册的顺序
+func (c *Configure) Parsers() []string {
+	return slices.Clone(c.parseNames)
+}
+
+// RemoveParser 删除 fileExt 对应的 parser，返回是否存在
+func (c *Configure) RemoveParser(fileExt string) bool {
+	if _, has := c.parsers[fileExt]; !has {
+		return false
+	}
+	delete(c.parsers, fileExt)
+	c.parseNames = slices.DeleteFunc(c.parseNames, func(s string) bool {
+		return s == fileExt
+	})
+	return true
+}
+
+// ReplaceParser 替换 fileExt 对应的 parser，若不存在会返回错误
+func (c *Configure) ReplaceParser(fileExt string, fn DecoderFunc) error {
+	if _, has := c.parsers[fileExt]; !has {
+		return fmt.Errorf("parser=%q not found", fileExt)
+	}
+	c.parsers[fileExt] = fn
+	return nil
+}
+
+// WithoutParsers 返回新的对象，并删除指定文件后缀的 parser
+func (c *Configure) WithoutParsers(fileExts ...string) *Configure {
+	c1 := c.Clone()
+	for _, ext := range fileExts {
+		c1.RemoveParser(ext)
+	}
+	return c1
+}
+
+// Hooks 返回所有已注册的 Hook，按照执行的顺序
+func (c *Configure) Hooks() []Hook {
+	return c.hooks.list()
+}
+
+// RemoveHook 删除名为 name 的 Hook，返回是否存在
+func (c *Configure) RemoveHook(name string) bool {
+	return c.hooks.Remove(name)
+}
+
+// ReplaceHook 使用 h 替换同名的 Hook，执行的位置保持不变，若不存在会返回错误
+func (c *Configure) ReplaceHook(h Hook) error {
+	return c.hooks.Replace(h)
+}
+
+// WithoutHooks 返回新的对象，并删除指定名称的 Hook
+func (c *Configure) WithoutHooks(names ...string) *Configure {
+	c1 := c.Clone()
+	for _, name := range names {
+		c1.RemoveHook(name)
+	}
+	return c1
+}
+
 // RegisterHook 注册新的 Hook，若出现重名会注册失败
+// 按照 Hook 的优先级执行，见 PriorityHook，优先级相同时按照注册的顺序执行
 func (c *Configure) RegisterHook(h Hook) error {
 	return c.hooks.Add(h)
 }
 
+// RegisterHookBefore 将 Hook 注册到名为 target 的 Hook 之前执行，并使用 target 的优先级
+func (c *Configure) RegisterHookBefore(target string, h Hook) error {
+	return c.hooks.AddAt(target, false, h)
+}
+
+// RegisterHookAfter 将 Hook 注册到名为 target 的 Hook 之后执行，并使用 target 的优先级
+func (c *Configure) RegisterHookAfter(target string, h Hook) error {
+	return c.hooks.AddAt(target, true, h)
+}
+
 // MustRegisterHook 注册新的 Hook, 若失败会 panic
 func (c *Configure) MustRegisterHook(h Hook) {
 	if err := c.hooks.Add(h); err != nil {
@@ -240,13 +477,39 @@ func (c *Configure) MustRegisterHook(h Hook) {
 
 func (c *Configure) Clone() *Configure {
 	c1 := &Configure{
-		parsers:  make(map[string]DecoderFunc, len(c.parsers)),
-		validate: c.validate,
+		ctx:        c.ctx,
+		parsers:    make(map[string]DecoderFunc, len(c.parsers)),
+		parseNames: append([]string{}, c.parseNames...),
+		extOrder:   slices.Clone(c.extOrder),
+		strictExt:  c.strictExt,
+
+		searchPaths: slices.Clone(c.searchPaths),
+		searchMode:  c.searchMode,
+		tplFuncs:    maps.Clone(c.tplFuncs),
+		validate:    c.validate,
+
+		profileOverlay: c.profileOverlay,
+		envOverride:    c.envOverride,
+		redact:         c.redact,
+		env:            c.env,
+		secretTTL:      c.secretTTL,
+		secrets:        c.secrets,
+		keyProvider:    c.keyProvider,
+		envFiles:       append([]string{}, c.envFiles...),
+		fsys:           c.fsys,
 	}
 	for n, fn := range c.parsers {
 		c1.parsers[n] = fn
 	}
-	c1.hooks = append([]Hook{}, c.hooks...)
+	c1.encoders = make(map[string]EncoderFunc, len(c.encoders))
+	for n, fn := range c.encoders {
+		c1.encoders[n] = fn
+	}
+	c1.secretProviders = make(map[string]SecretProvider, len(c.secretProviders))
+	for n, p := range c.secretProviders {
+		c1.secretProviders[n] = p
+	}
+	c1.hooks = slices.Clone(c.hooks)
 	return c1
 }
 
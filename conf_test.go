@@ -6,6 +6,7 @@ package fsconf
 
 import (
 	"reflect"
+	"slices"
 	"testing"
 
 	"github.com/fsgo/fst"
@@ -25,14 +26,14 @@ func Test_confImpl(t *testing.T) {
 }
 
 func TestNewDefault1(t *testing.T) {
-	hd := append([]Hook{}, defaultHooks...)
+	hd := append(hooks{}, defaultHooks...)
 	defer func() {
 		defaultHooks = hd
 		if re := recover(); re == nil {
 			t.Errorf("want panic")
 		}
 	}()
-	h := newHook("test", hook.OsEnvVars)
+	h := newHookEntry(newHook("test", hook.OsEnvVars), DefaultHookPriority)
 	// helper 有重复的时候
 	defaultHooks = append(defaultHooks, h, h)
 	NewDefault()
@@ -85,3 +86,99 @@ func Test_confImpl_ParseBytes(t *testing.T) {
 		})
 	}
 }
+
+func TestParse_ini(t *testing.T) {
+	t.Setenv("FSCONF_TEST_INI_PORT", "8080")
+	type config struct {
+		Name string `ini:"name"`
+		DB   struct {
+			Port int `ini:"port"`
+		} `ini:"db"`
+	}
+	var cfg config
+	fst.NoError(t, NewDefault().ParseBytes(".ini", []byte("name = demo\n[db]\nport = {osenv.FSCONF_TEST_INI_PORT}\n"), &cfg))
+	fst.Equal(t, "demo", cfg.Name)
+	fst.Equal(t, 8080, cfg.DB.Port)
+}
+
+func TestParse_properties(t *testing.T) {
+	type config struct {
+		DB struct {
+			Primary struct {
+				Host string `json:"host"`
+				Port int    `json:"port"`
+			} `json:"primary"`
+		} `json:"db"`
+	}
+	var cfg config
+	fst.NoError(t, NewDefault().ParseBytes(".properties", []byte("db.primary.host=127.0.0.1\ndb.primary.port=3306\n"), &cfg))
+	fst.Equal(t, "127.0.0.1", cfg.DB.Primary.Host)
+	fst.Equal(t, 3306, cfg.DB.Primary.Port)
+}
+
+func TestParse_json5(t *testing.T) {
+	var got map[string]string
+	content := "{\n  // comment\n  name: 'demo', /* c */\n  \"url\": \"http://a.com/#x\",\n}"
+	fst.NoError(t, NewDefault().ParseBytes(".json5", []byte(content), &got))
+	fst.Equal(t, map[string]string{"name": "demo", "url": "http://a.com/#x"}, got)
+	fst.Error(t, NewDefault().ParseBytes(".jsonc", []byte(content), &got))
+}
+
+func TestConfigure_hookRegistry(t *testing.T) {
+	conf := NewDefault()
+	names := hookNames(conf.hooks)
+	fst.Len(t, conf.Hooks(), len(names))
+	fst.Equal(t, "template", conf.Hooks()[0].Name())
+
+	c1 := conf.WithoutHooks("fsenv", "not_found")
+	fst.Len(t, c1.Hooks(), len(names)-1)
+	fst.Len(t, conf.Hooks(), len(names))
+
+	fst.True(t, c1.RemoveHook("osenv"))
+	fst.False(t, c1.RemoveHook("osenv"))
+	fst.Len(t, c1.Hooks(), len(names)-2)
+
+	h := &testOrderHook{name: "osenv", priority: 1}
+	fst.Error(t, c1.ReplaceHook(h))
+	fst.NoError(t, conf.ReplaceHook(h))
+	fst.Equal(t, names, hookNames(conf.hooks))
+	fst.Equal[Hook](t, h, conf.Hooks()[1])
+
+	// 内容中包含 {fsenv. 文本
+	var got map[string]string
+	content := []byte(`{"A":"{fsenv.abc}"}`)
+	fst.Error(t, NewDefault().ParseBytes(".json", content, &got))
+	fst.NoError(t, NewDefault().WithoutHooks("fsenv").ParseBytes(".json", content, &got))
+	fst.Equal(t, map[string]string{"A": "{fsenv.abc}"}, got)
+	fst.NoError(t, NewDefault(ExcludeHooks("fsenv")).ParseBytes(".json", content, &got))
+}
+
+func TestConfigure_parserRegistry(t *testing.T) {
+	conf := NewDefault()
+	exts := conf.Parsers()
+	fst.Equal(t, ".json", exts[0])
+
+	c1 := conf.WithoutParsers(".xml")
+	fst.Len(t, c1.Parsers(), len(exts)-1)
+	fst.Len(t, conf.Parsers(), len(exts))
+	fst.False(t, c1.RemoveParser(".xml"))
+	var got map[string]any
+	fst.Error(t, c1.ParseBytes(".xml", []byte("<a></a>"), &got))
+
+	fst.Error(t, c1.ReplaceParser(".xml", parser.JSON))
+	fst.NoError(t, c1.ReplaceParser(".ini", parser.JSON))
+	var got1 map[string]int
+	fst.NoError(t, c1.ParseBytes(".ini", []byte(`{"A":1}`), &got1))
+	fst.Equal(t, map[string]int{"A": 1}, got1)
+}
+
+func TestNewDefault_options(t *testing.T) {
+	conf := NewDefault(OnlyHooks("osenv", "template"), OnlyParsers(".json", ".ini"))
+	fst.Equal(t, []string{"template", "osenv"}, hookNames(conf.hooks))
+	fst.Equal(t, []string{".json", ".ini"}, conf.Parsers())
+
+	conf = NewDefault(ExcludeHooks("template"), ExcludeParsers(".json"))
+	fst.False(t, slices.Contains(hookNames(conf.hooks), "template"))
+	fst.False(t, slices.Contains(conf.Parsers(), ".json"))
+	fst.True(t, slices.Contains(conf.Parsers(), ".xml"))
+}
@@ -6,7 +6,11 @@ package fsconf
 
 import (
 	"context"
+	"io/fs"
+	"slices"
 	"sync/atomic"
+
+	"github.com/fsgo/fsenv"
 )
 
 var defaultCfg atomic.Pointer[Configure]
@@ -66,10 +70,41 @@ func MustParseBytes(fileExt string, content []byte, obj any) {
 //
 //	confName 的文件后缀是可选的，当查找文件不存在时，会添加上支持的后缀依次去判断。
 //	如 Exists("app.toml") 会去 {ConfDir}/app.toml 判断
+//	若需要知道实际使用的是哪个文件，请使用 Locate
 func Exists(confName string) bool {
 	return Default().Exists(confName)
 }
 
+// SetExtensionOrder （全局）设置配置文件名不包含后缀时，查找文件使用的后缀顺序
+func SetExtensionOrder(exts ...string) {
+	Default().SetExtensionOrder(exts...)
+}
+
+// SetStrictExtension （全局）设置是否使用严格模式查找配置文件
+func SetStrictExtension(strict bool) {
+	Default().SetStrictExtension(strict)
+}
+
+// SetSearchPaths （全局）设置查找配置文件的目录
+func SetSearchPaths(paths ...string) {
+	Default().SetSearchPaths(paths...)
+}
+
+// SetSearchMode （全局）设置查找配置文件的方式
+func SetSearchMode(mode SearchMode) {
+	Default().SetSearchMode(mode)
+}
+
+// Locate （全局）返回 confName 实际使用的配置文件
+func Locate(confName string) ([]string, error) {
+	return Default().Locate(confName)
+}
+
+// RegisterTemplateFunc （全局）注册 template hook 中可以使用的函数
+func RegisterTemplateFunc(name string, fn any) error {
+	return Default().RegisterTemplateFunc(name, fn)
+}
+
 // RegisterParser （全局）注册一个解析器
 // fileExt 是文件后缀，如 .json
 func RegisterParser(fileExt string, fn DecoderFunc) error {
@@ -81,6 +116,37 @@ func RegisterParser(fileExt string, fn DecoderFunc) error {
 	return nil
 }
 
+// Parsers （全局）返回所有已注册 parser 的文件后缀
+func Parsers() []string {
+	return Default().Parsers()
+}
+
+// RemoveParser （全局）删除 fileExt 对应的 parser，之后 NewDefault 创建的对象也不再包含
+func RemoveParser(fileExt string) bool {
+	defaultParsers = slices.DeleteFunc(defaultParsers, func(p parserNameFn) bool {
+		return p.Name == fileExt
+	})
+	return Default().RemoveParser(fileExt)
+}
+
+// ReplaceParser （全局）替换 fileExt 对应的 parser，之后 NewDefault 创建的对象也会使用新的 parser
+func ReplaceParser(fileExt string, fn DecoderFunc) error {
+	if err := Default().ReplaceParser(fileExt, fn); err != nil {
+		return err
+	}
+	for i, p := range defaultParsers {
+		if p.Name == fileExt {
+			defaultParsers[i].Fn = fn
+		}
+	}
+	return nil
+}
+
+// WithoutParsers （全局）返回新的对象，并删除指定文件后缀的 parser
+func WithoutParsers(fileExts ...string) *Configure {
+	return Default().WithoutParsers(fileExts...)
+}
+
 // MustRegisterParser 调用 RegisterParser，若返回的 err!=nil 则 panic
 func MustRegisterParser(fileExt string, fn DecoderFunc) {
 	if err := RegisterParser(fileExt, fn); err != nil {
@@ -103,6 +169,47 @@ func MustRegisterHook(h Hook) {
 	}
 }
 
+// Hooks （全局）返回所有已注册的 Hook
+func Hooks() []Hook {
+	return Default().Hooks()
+}
+
+// RemoveHook （全局）删除名为 name 的 Hook，之后 NewDefault 创建的对象也不再包含
+func RemoveHook(name string) bool {
+	defaultHooks.Remove(name)
+	return Default().RemoveHook(name)
+}
+
+// ReplaceHook （全局）替换同名的 Hook，之后 NewDefault 创建的对象也会使用新的 Hook
+func ReplaceHook(h Hook) error {
+	if err := Default().ReplaceHook(h); err != nil {
+		return err
+	}
+	_ = defaultHooks.Replace(h)
+	return nil
+}
+
+// WithoutHooks （全局）返回新的对象，并删除指定名称的 Hook
+func WithoutHooks(names ...string) *Configure {
+	return Default().WithoutHooks(names...)
+}
+
+// RegisterHookBefore （全局）将 Hook 注册到名为 target 的 Hook 之前执行
+func RegisterHookBefore(target string, h Hook) error {
+	if err := defaultHooks.AddAt(target, false, h); err != nil {
+		return err
+	}
+	return Default().RegisterHookBefore(target, h)
+}
+
+// RegisterHookAfter （全局）将 Hook 注册到名为 target 的 Hook 之后执行
+func RegisterHookAfter(target string, h Hook) error {
+	if err := defaultHooks.AddAt(target, true, h); err != nil {
+		return err
+	}
+	return Default().RegisterHookAfter(target, h)
+}
+
 // WithContext （全局）返回新的对象,并设置新的 ctx
 func WithContext(ctx context.Context) *Configure {
 	return Default().WithContext(ctx)
@@ -112,3 +219,106 @@ func WithContext(ctx context.Context) *Configure {
 func WithHook(hs ...Hook) *Configure {
 	return Default().WithHook(hs...)
 }
+
+// Watch （全局）解析配置并监听配置文件的变化，ctx 结束后停止监听
+func Watch(ctx context.Context, confName string, obj any, onChange OnChangeFunc, opts ...WatchOption) error {
+	return Default().Watch(ctx, confName, obj, onChange, opts...)
+}
+
+// ParseDir （全局）读取目录中所有支持的配置文件，按照文件名的字典序深度合并后再解析到 obj 上
+func ParseDir(dir string, obj any) error {
+	return Default().ParseDir(dir, obj)
+}
+
+// ParseLayers （全局）依次读取多个配置文件，深度合并后再解析到 obj 上
+func ParseLayers(obj any, confNames ...string) error {
+	return Default().ParseLayers(obj, confNames...)
+}
+
+// SetProfileOverlay （全局）设置是否自动合并 RunMode 和 IDC 对应的配置文件
+func SetProfileOverlay(enable bool) {
+	Default().SetProfileOverlay(enable)
+}
+
+// SetEnvOverride （全局）设置使用环境变量覆盖配置字段的规则
+func SetEnvOverride(eo *EnvOverride) {
+	Default().SetEnvOverride(eo)
+}
+
+// Dump （全局）将配置对象以 JSON 格式输出，用于调试，敏感的值会被隐藏
+func Dump(obj any) ([]byte, error) {
+	return Default().Dump(obj)
+}
+
+// ParseWithTrace （全局）解析配置，并返回每个值的来源
+func ParseWithTrace(confName string, obj any) (*Trace, error) {
+	return Default().ParseWithTrace(confName, obj)
+}
+
+// Explain （全局）解析配置，并返回每个值的来源
+func Explain(confName string) (*Trace, error) {
+	return Default().Explain(confName)
+}
+
+// WithFS （全局）返回新的对象，使用 fsys 读取配置文件
+func WithFS(fsys fs.FS) *Configure {
+	return Default().WithFS(fsys)
+}
+
+// SetEnv （全局）设置应用的环境信息
+func SetEnv(env *fsenv.Attribute) {
+	Default().SetEnv(env)
+}
+
+// WithEnv （全局）返回新的对象，并设置应用的环境信息
+func WithEnv(env *fsenv.Attribute) *Configure {
+	return Default().WithEnv(env)
+}
+
+// RegisterSecretProvider （全局）注册 SecretProvider
+func RegisterSecretProvider(p SecretProvider) error {
+	return Default().RegisterSecretProvider(p)
+}
+
+// SetKeyProvider （全局）设置解密配置中加密内容所使用的密钥的提供者
+func SetKeyProvider(kp KeyProvider) {
+	Default().SetKeyProvider(kp)
+}
+
+// SetEnvFiles （全局）设置 osenv Hook 额外读取变量的 .env 文件
+func SetEnvFiles(files ...string) {
+	Default().SetEnvFiles(files...)
+}
+
+// RegisterEncoder （全局）注册 fileExt 对应的 encoder，之后 NewDefault 创建的对象也会包含
+func RegisterEncoder(fileExt string, fn EncoderFunc) error {
+	err := Default().RegisterEncoder(fileExt, fn)
+	if err != nil {
+		return err
+	}
+	defaultEncoders[fileExt] = fn
+	return nil
+}
+
+// RegisterCodec （全局）同时注册 fileExt 对应的 parser 和 encoder，之后 NewDefault 创建的对象也会包含
+func RegisterCodec(fileExt string, codec Codec) error {
+	err := Default().RegisterCodec(fileExt, codec)
+	if err != nil {
+		return err
+	}
+	defaultParsers = append(defaultParsers, parserNameFn{Name: fileExt, Fn: codec.Decoder})
+	if codec.Encoder != nil {
+		defaultEncoders[fileExt] = codec.Encoder
+	}
+	return nil
+}
+
+// Marshal （全局）使用 fileExt 对应的 encoder 编码 obj
+func Marshal(fileExt string, obj any) ([]byte, error) {
+	return Default().Marshal(fileExt, obj)
+}
+
+// Write （全局）将 obj 编码后写入配置文件
+func Write(confName string, obj any) error {
+	return Default().Write(confName, obj)
+}
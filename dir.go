@@ -0,0 +1,108 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/fsgo/fsconf/internal/tree"
+)
+
+// ParseDir 读取目录中所有支持的配置文件（如 conf.d 目录），按照文件名的字典序深度合并后再解析到 obj 上，
+// 后面的文件会覆盖前面文件中的同名配置。
+//
+// 只读取目录下一层的文件，忽略子目录、以 "." 开头的文件以及没有对应 parser 的文件（如 README.md）。
+// 和 ParseLayers 一样，每个文件可以使用不同的格式，不支持 .xml 格式。
+//
+// 当 Parse 的配置文件是一个目录，且不存在同名的配置文件时（如 Parse("app.d") ），也会使用此方式解析。
+func (c *Configure) ParseDir(dir string, obj any) error {
+	dirPath, err := c.confFileAbsPath(dir)
+	if err != nil {
+		return err
+	}
+	info, err := c.statFile(dirPath)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%q is not a directory", dirPath)
+	}
+	data, err := c.readDirLayer(dirPath)
+	if err != nil {
+		return c.redactError(err)
+	}
+	return c.redactError(c.decodeTree(data, obj))
+}
+
+// dropInDir confPath 是否是一个需要按照目录解析的配置
+func (c *Configure) dropInDir(confPath string) bool {
+	info, err := c.statFile(confPath)
+	if err != nil || !info.IsDir() {
+		return false
+	}
+	// 存在同名的配置文件时，使用配置文件
+	_, _, found, err := c.probeExt(confPath)
+	return err == nil && !found
+}
+
+// dirFiles 返回目录中所有支持的配置文件，按照文件名排序
+func (c *Configure) dirFiles(dir string) ([]string, error) {
+	var entries []fs.DirEntry
+	var err error
+	if c.fsys != nil {
+		entries, err = fs.ReadDir(c.fsys, dir)
+	} else {
+		entries, err = os.ReadDir(dir)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || strings.HasPrefix(name, ".") {
+			continue
+		}
+		if _, has := c.parsers[filepath.Ext(name)]; !has {
+			continue
+		}
+		files = append(files, c.joinPath(dir, name))
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no config file found in directory %q: %w", dir, fs.ErrNotExist)
+	}
+	return files, nil
+}
+
+// readDirLayer 读取目录中所有的配置文件，并合并为通用的数据结构
+func (c *Configure) readDirLayer(dir string) (any, error) {
+	// 记录目录中的文件，以便 Watch 能够发现新增和删除的文件
+	trackGlob(c.context(), c.joinPath(dir, "*"))
+	files, err := c.dirFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	var merged any
+	for _, fp := range files {
+		data, _, err := c.readLayerByAbsPath(fp, false)
+		if err != nil {
+			return nil, err
+		}
+		merged = tree.Merge(merged, data)
+	}
+	return merged, nil
+}
+
+func (c *Configure) joinPath(dir string, name string) string {
+	if c.fsys != nil {
+		return path.Join(dir, name)
+	}
+	return filepath.Join(dir, name)
+}
@@ -0,0 +1,114 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/fsgo/fst"
+)
+
+func TestConfigure_ParseDir(t *testing.T) {
+	fsys := fstest.MapFS{
+		"conf/app.d/10-base.json":    {Data: []byte(`{"Name":"base","DB":{"Host":"127.0.0.1","Port":3306}}`)},
+		"conf/app.d/20-db.ini":       {Data: []byte("[DB]\nPort = 3307\n")},
+		"conf/app.d/.30-hidden.json": {Data: []byte(`{"Name":"hidden"}`)},
+		"conf/app.d/README.md":       {Data: []byte("# readme")},
+		"conf/app.d/sub/x.json":      {Data: []byte(`{"Name":"sub"}`)},
+		"conf/empty.d/README.md":     {Data: []byte("# readme")},
+		"conf/db.json":               {Data: []byte(`{"Name":"db"}`)},
+		"conf/db/x.json":             {Data: []byte(`{"Name":"db_dir"}`)},
+	}
+	type config struct {
+		Name string
+		DB   struct {
+			Host string
+			Port int
+		}
+	}
+	conf := NewDefault().WithFS(fsys)
+
+	check := func(t *testing.T, got config) {
+		fst.Equal(t, "base", got.Name)
+		fst.Equal(t, "127.0.0.1", got.DB.Host)
+		fst.Equal(t, 3307, got.DB.Port)
+	}
+
+	t.Run("ParseDir", func(t *testing.T) {
+		var got config
+		fst.NoError(t, conf.ParseDir("conf/app.d", &got))
+		check(t, got)
+
+		fst.Error(t, conf.ParseDir("conf/db.json", &got))
+		fst.Error(t, conf.ParseDir("conf/not_found", &got))
+		fst.Error(t, conf.ParseDir("conf/empty.d", &got))
+	})
+
+	t.Run("Parse", func(t *testing.T) {
+		var got config
+		fst.NoError(t, conf.Parse("conf/app.d", &got))
+		check(t, got)
+		fst.True(t, conf.Exists("conf/app.d"))
+		fst.False(t, conf.Exists("conf/empty.d"))
+		fst.Error(t, conf.Parse("conf/empty.d", &got))
+
+		files, err := conf.Locate("conf/app.d")
+		fst.NoError(t, err)
+		fst.Equal(t, []string{"conf/app.d"}, files)
+
+		// 存在同名的配置文件时，使用配置文件
+		fst.NoError(t, conf.Parse("conf/db", &got))
+		fst.Equal(t, "db", got.Name)
+	})
+
+	t.Run("ParseLayers", func(t *testing.T) {
+		var got config
+		fst.NoError(t, conf.ParseLayers(&got, "conf/db.json", "conf/app.d", "conf/not_found.d"))
+		check(t, got)
+	})
+
+	t.Run("trace", func(t *testing.T) {
+		var got config
+		tr, err := conf.ParseWithTrace("conf/app.d", &got)
+		fst.NoError(t, err)
+		fst.Equal(t, "conf/app.d/20-db.ini", tr.Get("DB.Port").File)
+		fst.Equal(t, "conf/app.d/10-base.json", tr.Get("DB.Host").File)
+	})
+}
+
+func TestConfigure_Watch_dir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "app.d")
+	fst.NoError(t, os.MkdirAll(dir, 0755))
+	fst.NoError(t, os.WriteFile(filepath.Join(dir, "10-a.json"), []byte(`{"A":"a1"}`), 0644))
+
+	type cfg struct {
+		A string
+		B string
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := make(chan any, 10)
+
+	var c1 cfg
+	err := NewDefault().Watch(ctx, dir, &c1, func(obj any, err error) {
+		events <- obj
+	}, WatchInterval(10*time.Millisecond), WatchDebounce(20*time.Millisecond))
+	fst.NoError(t, err)
+	fst.Equal(t, cfg{A: "a1"}, c1)
+
+	fst.NoError(t, os.WriteFile(filepath.Join(dir, "20-b.json"), []byte(`{"B":"b1"}`), 0644))
+	select {
+	case obj := <-events:
+		fst.Equal[any](t, &cfg{A: "a1", B: "b1"}, obj)
+	case <-time.After(3 * time.Second):
+		t.Fatal("wait onChange timeout")
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/fsgo/fsconf/internal/parser"
+)
+
+// EncoderFunc 针对特定文件后缀的配置编码方法，是 DecoderFunc 的逆操作
+type EncoderFunc func(obj any) ([]byte, error)
+
+// Codec 一种配置格式的解析和编码方法
+type Codec struct {
+	Decoder DecoderFunc
+	Encoder EncoderFunc
+}
+
+// defaultEncoders 所有默认的 encoder
+var defaultEncoders = map[string]EncoderFunc{
+	".json":       parser.EncodeJSON,
+	".jsonc":      parser.EncodeJSON,
+	".json5":      parser.EncodeJSON,
+	".xml":        encodeXML,
+	".ini":        parser.EncodeINI,
+	".env":        parser.EncodeDotEnv,
+	".properties": parser.EncodeProperties,
+}
+
+func encodeXML(obj any) ([]byte, error) {
+	bf, err := xml.MarshalIndent(obj, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(bf, '\n'), nil
+}
+
+// RegisterEncoder 注册 fileExt 对应的 encoder，若已存在会注册失败
+func (c *Configure) RegisterEncoder(fileExt string, fn EncoderFunc) error {
+	if _, has := c.encoders[fileExt]; has {
+		return fmt.Errorf("encoder=%q already exists", fileExt)
+	}
+	if c.encoders == nil {
+		c.encoders = map[string]EncoderFunc{}
+	}
+	c.encoders[fileExt] = fn
+	return nil
+}
+
+// RegisterCodec 同时注册 fileExt 对应的 parser 和 encoder，Encoder 可以为 nil
+func (c *Configure) RegisterCodec(fileExt string, codec Codec) error {
+	if codec.Decoder == nil {
+		return errors.New("codec.Decoder is nil")
+	}
+	if codec.Encoder != nil {
+		if _, has := c.encoders[fileExt]; has {
+			return fmt.Errorf("encoder=%q already exists", fileExt)
+		}
+	}
+	if err := c.RegisterParser(fileExt, codec.Decoder); err != nil {
+		return err
+	}
+	if codec.Encoder == nil {
+		return nil
+	}
+	return c.RegisterEncoder(fileExt, codec.Encoder)
+}
+
+// Marshal 使用 fileExt 对应的 encoder 编码 obj
+func (c *Configure) Marshal(fileExt string, obj any) ([]byte, error) {
+	fn, ok := c.encoders[fileExt]
+	if !ok {
+		return nil, fmt.Errorf("encoder for fileExt %q is not supported yet", fileExt)
+	}
+	return fn(obj)
+}
+
+// Write 将 obj 编码后写入配置文件，格式由文件后缀决定
+//
+// confName 的查找规则和 Parse 相同，当不包含后缀时，会使用已存在的配置文件。
+// 会先写入同目录下的临时文件再重命名，以保证写入是原子的，若文件已存在，会保留原文件的权限。
+// 使用 WithFS 时，不支持写入。
+func (c *Configure) Write(confName string, obj any) error {
+	if c.fsys != nil {
+		return errors.New("cannot write config when using fs.FS")
+	}
+	fp, err := c.confFileAbsPath(confName)
+	if err != nil {
+		return err
+	}
+	fileExt := filepath.Ext(fp)
+	if _, has := c.encoders[fileExt]; !has {
+		if realFile, ext, err1 := c.realConfPath(fp); err1 == nil {
+			fp, fileExt = realFile, ext
+		}
+	}
+	bf, err := c.Marshal(fileExt, obj)
+	if err != nil {
+		return fmt.Errorf("marshal %q failed: %w", fp, err)
+	}
+	return writeFileAtomic(fp, bf)
+}
+
+// writeFileAtomic 先写入临时文件，再重命名为 fp
+func writeFileAtomic(fp string, content []byte) (err error) {
+	perm := fs.FileMode(0644)
+	if info, err1 := os.Stat(fp); err1 == nil {
+		if info.IsDir() {
+			return fmt.Errorf("%q is a directory", fp)
+		}
+		perm = info.Mode().Perm()
+	}
+	f, err := os.CreateTemp(filepath.Dir(fp), "."+filepath.Base(fp)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = f.Close()
+			_ = os.Remove(f.Name())
+		}
+	}()
+	if _, err = f.Write(content); err != nil {
+		return err
+	}
+	if err = f.Sync(); err != nil {
+		return err
+	}
+	if err = f.Chmod(perm); err != nil {
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), fp)
+}
@@ -0,0 +1,140 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/fsgo/fst"
+)
+
+type testEncodeConfig struct {
+	Name    string        `json:"name" ini:"name"`
+	Timeout time.Duration `json:"timeout" ini:"timeout"`
+	DB      struct {
+		Host  string   `json:"host" ini:"host"`
+		Port  int      `json:"port" ini:"port"`
+		Hosts []string `json:"hosts" ini:"hosts"`
+	} `json:"db" ini:"db"`
+}
+
+func TestMarshal(t *testing.T) {
+	var cfg testEncodeConfig
+	cfg.Name = "demo ; x"
+	cfg.Timeout = time.Second
+	cfg.DB.Host = "127.0.0.1"
+	cfg.DB.Port = 3306
+	cfg.DB.Hosts = []string{"a", "b"}
+
+	for _, ext := range []string{".json", ".json5", ".ini"} {
+		t.Run(ext, func(t *testing.T) {
+			bf, err := Marshal(ext, cfg)
+			fst.NoError(t, err)
+			var got testEncodeConfig
+			fst.NoError(t, NewDefault().ParseBytes(ext, bf, &got))
+			fst.Equal(t, cfg, got)
+		})
+	}
+
+	t.Run(".properties", func(t *testing.T) {
+		cfg1 := cfg
+		cfg1.DB.Hosts = nil
+		bf, err := Marshal(".properties", cfg1)
+		fst.NoError(t, err)
+		var got testEncodeConfig
+		fst.NoError(t, NewDefault().ParseBytes(".properties", bf, &got))
+		fst.Equal(t, cfg1, got)
+
+		_, err = Marshal(".properties", cfg)
+		fst.Error(t, err)
+	})
+
+	t.Run(".env", func(t *testing.T) {
+		m := map[string]string{"NAME": "a b \"c\"", "PORT": "80"}
+		bf, err := Marshal(".env", m)
+		fst.NoError(t, err)
+		var got map[string]string
+		fst.NoError(t, NewDefault().ParseBytes(".env", bf, &got))
+		fst.Equal(t, m, got)
+
+		_, err = Marshal(".env", cfg)
+		fst.Error(t, err)
+	})
+
+	t.Run("not supported", func(t *testing.T) {
+		_, err := Marshal(".abc", cfg)
+		fst.Error(t, err)
+	})
+}
+
+func TestConfigure_Write(t *testing.T) {
+	dir := t.TempDir()
+	fp := filepath.Join(dir, "app.json")
+	conf := NewDefault()
+
+	fst.NoError(t, conf.Write(fp, map[string]any{"A": 1}))
+	info, err := os.Stat(fp)
+	fst.NoError(t, err)
+	fst.Equal(t, os.FileMode(0644), info.Mode().Perm())
+
+	fst.NoError(t, os.Chmod(fp, 0600))
+	// 不带后缀时，使用已存在的文件
+	fst.NoError(t, conf.Write(filepath.Join(dir, "app"), map[string]any{"A": 2}))
+	info, err = os.Stat(fp)
+	fst.NoError(t, err)
+	fst.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	var got map[string]int
+	fst.NoError(t, conf.Parse(fp, &got))
+	fst.Equal(t, map[string]int{"A": 2}, got)
+
+	entries, err := os.ReadDir(dir)
+	fst.NoError(t, err)
+	fst.Len(t, entries, 1)
+
+	fst.Error(t, conf.Write(filepath.Join(dir, "app.abc"), got))
+	fst.Error(t, conf.WithFS(fstest.MapFS{}).Write("app.json", got))
+}
+
+func TestConfigure_RegisterCodec(t *testing.T) {
+	conf := NewDefault()
+	fst.Error(t, conf.RegisterEncoder(".json", nil))
+	fst.Error(t, conf.RegisterCodec(".json", Codec{Decoder: json.Unmarshal, Encoder: json.Marshal}))
+	fst.Error(t, conf.RegisterCodec(".abc", Codec{}))
+	fst.NoError(t, conf.RegisterCodec(".abc", Codec{Decoder: json.Unmarshal, Encoder: json.Marshal}))
+	bf, err := conf.Marshal(".abc", map[string]int{"A": 1})
+	fst.NoError(t, err)
+	var got map[string]int
+	fst.NoError(t, conf.ParseBytes(".abc", bf, &got))
+	fst.Equal(t, map[string]int{"A": 1}, got)
+}
+
+func TestRegisterCodec(t *testing.T) {
+	defer func() {
+		RemoveParser(".fsconf_codec")
+		delete(defaultEncoders, ".fsconf_codec")
+		delete(defaultEncoders, ".fsconf_enc")
+		delete(Default().encoders, ".fsconf_codec")
+		delete(Default().encoders, ".fsconf_enc")
+	}()
+	fst.NoError(t, RegisterEncoder(".fsconf_enc", json.Marshal))
+	fst.Error(t, RegisterEncoder(".fsconf_enc", json.Marshal))
+	fst.NoError(t, RegisterCodec(".fsconf_codec", Codec{Decoder: json.Unmarshal, Encoder: json.Marshal}))
+
+	conf := NewDefault()
+	for _, ext := range []string{".fsconf_enc", ".fsconf_codec"} {
+		bf, err := conf.Marshal(ext, map[string]int{"A": 1})
+		fst.NoError(t, err)
+		fst.Equal(t, `{"A":1}`, string(bf))
+	}
+	var got map[string]int
+	fst.NoError(t, conf.ParseBytes(".fsconf_codec", []byte(`{"A":1}`), &got))
+	fst.Equal(t, map[string]int{"A": 1}, got)
+}
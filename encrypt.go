@@ -0,0 +1,177 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// EncAlgorithm 加密算法，目前只支持 AES256-GCM
+const EncAlgorithm = "AES256-GCM"
+
+// encKeyLen AES256 的密钥长度
+const encKeyLen = 32
+
+// KeyProvider 解密配置中加密内容所使用的密钥的提供者
+type KeyProvider interface {
+	// GetKey 返回 32 字节的密钥
+	GetKey(ctx context.Context) ([]byte, error)
+}
+
+// KeyProviderFunc 函数类型的 KeyProvider
+type KeyProviderFunc func(ctx context.Context) ([]byte, error)
+
+// GetKey 实现 KeyProvider 接口
+func (f KeyProviderFunc) GetKey(ctx context.Context) ([]byte, error) {
+	return f(ctx)
+}
+
+// StaticKey 使用固定的密钥
+func StaticKey(key []byte) KeyProvider {
+	return KeyProviderFunc(func(_ context.Context) ([]byte, error) {
+		return key, nil
+	})
+}
+
+// KeyFromEnv 从环境变量中读取 base64 编码的密钥
+func KeyFromEnv(name string) KeyProvider {
+	return KeyProviderFunc(func(_ context.Context) ([]byte, error) {
+		v, ok := os.LookupEnv(name)
+		if !ok {
+			return nil, fmt.Errorf("key env %q not found", name)
+		}
+		return decodeKey(v)
+	})
+}
+
+// KeyFromFile 从文件中读取 base64 编码的密钥
+func KeyFromFile(fp string) KeyProvider {
+	return KeyProviderFunc(func(_ context.Context) ([]byte, error) {
+		bf, err := os.ReadFile(fp)
+		if err != nil {
+			return nil, err
+		}
+		return decodeKey(string(bf))
+	})
+}
+
+func decodeKey(str string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(str))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64 key: %w", err)
+	}
+	return key, nil
+}
+
+// SetKeyProvider 设置解密配置中 {enc:AES256-GCM:xxx} 内容所使用的密钥的提供者
+func (c *Configure) SetKeyProvider(kp KeyProvider) {
+	c.keyProvider = kp
+}
+
+// Encrypt 使用 key 加密 plaintext，返回可以直接写入配置文件中的内容，如 {enc:AES256-GCM:xxx}
+//
+// key 的长度必须是 32 字节
+func Encrypt(key []byte, plaintext string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	return "{enc:" + EncAlgorithm + ":" + base64.StdEncoding.EncodeToString(sealed) + "}", nil
+}
+
+// decrypt 解密 Encrypt 返回内容中 base64 编码的部分
+func decrypt(key []byte, encoded string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	bf, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("invalid base64: %w", err)
+	}
+	if len(bf) < gcm.NonceSize() {
+		return "", errors.New("ciphertext too short")
+	}
+	plain, err := gcm.Open(nil, bf[:gcm.NonceSize()], bf[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != encKeyLen {
+		return nil, fmt.Errorf("invalid key length %d, want %d", len(key), encKeyLen)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+var _ Hook = (*hookEnc)(nil)
+
+// hookEnc 解密配置中的 {enc:AES256-GCM:xxx}，和 {secret.*} 一样，在双引号字符串中时，会对解密后的内容进行转义
+type hookEnc struct{}
+
+func (h *hookEnc) Name() string {
+	return "enc"
+}
+
+func (h *hookEnc) Priority() int {
+	return 500
+}
+
+// 模板变量格式：{enc:算法:base64 编码的内容}
+var encVarReg = regexp.MustCompile(`\{enc:([A-Za-z0-9-]+):([A-Za-z0-9+/=]*)\}`)
+
+func (h *hookEnc) Execute(ctx context.Context, p *HookParam) (output []byte, err error) {
+	if !encVarReg.Match(p.Content) {
+		return p.Content, nil
+	}
+	if p.Configure == nil || p.Configure.keyProvider == nil {
+		return nil, errors.New("found encrypted value but no KeyProvider, use SetKeyProvider to set it")
+	}
+	key, err := p.Configure.keyProvider.GetKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get key: %w", err)
+	}
+	return replaceValueVars(p.Content, encVarReg, p.FileExt, func(m [][]byte) (string, error) {
+		if string(m[1]) != EncAlgorithm {
+			return "", fmt.Errorf("line %d: unsupported algorithm %q", encLine(p.Content, m[0]), m[1])
+		}
+		val, err := decrypt(key, string(m[2]))
+		if err != nil {
+			return "", fmt.Errorf("line %d: decrypt failed: %w", encLine(p.Content, m[0]), err)
+		}
+		p.Configure.secrets.set("", val, -1)
+		return val, nil
+	})
+}
+
+// encLine 返回 sub 在 content 中第一次出现的行号
+func encLine(content []byte, sub []byte) int {
+	idx := bytes.Index(content, sub)
+	if idx < 0 {
+		return 0
+	}
+	return bytes.Count(content[:idx], []byte("\n")) + 1
+}
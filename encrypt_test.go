@@ -0,0 +1,114 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fsgo/fst"
+)
+
+func TestEncrypt(t *testing.T) {
+	key := bytes.Repeat([]byte("k"), 32)
+	enc, err := Encrypt(key, "hello-password")
+	fst.NoError(t, err)
+	fst.True(t, strings.HasPrefix(enc, "{enc:AES256-GCM:"))
+
+	enc2, err := Encrypt(key, "hello-password")
+	fst.NoError(t, err)
+	fst.NotEqual(t, enc, enc2)
+
+	_, err = Encrypt([]byte("short"), "abc")
+	fst.Error(t, err)
+}
+
+func TestConfigure_SetKeyProvider(t *testing.T) {
+	key := bytes.Repeat([]byte("k"), 32)
+	enc, err := Encrypt(key, "hello-password")
+	fst.NoError(t, err)
+	content := []byte(`{"Name":"demo","Password":"` + enc + `"}`)
+
+	type config struct {
+		Name     string
+		Password string
+	}
+
+	t.Run("static key", func(t *testing.T) {
+		conf := NewDefault()
+		conf.SetKeyProvider(StaticKey(key))
+		var cfg config
+		fst.NoError(t, conf.ParseBytes(".json", content, &cfg))
+		fst.Equal(t, config{Name: "demo", Password: "hello-password"}, cfg)
+
+		bf, err := conf.Dump(cfg)
+		fst.NoError(t, err)
+		fst.NotContains(t, string(bf), "hello-password")
+	})
+
+	t.Run("env key", func(t *testing.T) {
+		t.Setenv("FSCONF_TEST_KEY", base64.StdEncoding.EncodeToString(key))
+		conf := NewDefault()
+		conf.SetKeyProvider(KeyFromEnv("FSCONF_TEST_KEY"))
+		var cfg config
+		fst.NoError(t, conf.ParseBytes(".json", content, &cfg))
+		fst.Equal(t, "hello-password", cfg.Password)
+	})
+
+	t.Run("file key", func(t *testing.T) {
+		fp := filepath.Join(t.TempDir(), "key")
+		fst.NoError(t, os.WriteFile(fp, []byte(base64.StdEncoding.EncodeToString(key)+"\n"), 0600))
+		conf := NewDefault()
+		conf.SetKeyProvider(KeyFromFile(fp))
+		var cfg config
+		fst.NoError(t, conf.ParseBytes(".json", content, &cfg))
+		fst.Equal(t, "hello-password", cfg.Password)
+	})
+
+	t.Run("no key provider", func(t *testing.T) {
+		var cfg config
+		err := NewDefault().ParseBytes(".json", content, &cfg)
+		fst.Error(t, err)
+		fst.Contains(t, err.Error(), "no KeyProvider")
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		conf := NewDefault()
+		conf.SetKeyProvider(StaticKey(bytes.Repeat([]byte("x"), 32)))
+		var cfg config
+		err := conf.ParseBytes(".json", []byte("{\n\"Password\":\""+enc+"\"}"), &cfg)
+		fst.Error(t, err)
+		fst.Contains(t, err.Error(), "line 2: decrypt failed")
+	})
+
+	t.Run("unsupported algorithm", func(t *testing.T) {
+		conf := NewDefault()
+		conf.SetKeyProvider(StaticKey(key))
+		var cfg config
+		err := conf.ParseBytes(".json", []byte(`{"Password":"{enc:DES:abcd}"}`), &cfg)
+		fst.Error(t, err)
+		fst.Contains(t, err.Error(), `unsupported algorithm "DES"`)
+	})
+
+	t.Run("escape", func(t *testing.T) {
+		const plain = "p\"w\\d\n1"
+		enc2, err := Encrypt(key, plain)
+		fst.NoError(t, err)
+		conf := NewDefault()
+		conf.SetKeyProvider(StaticKey(key))
+		var cfg config
+		fst.NoError(t, conf.ParseBytes(".json", []byte(`{"Password":"`+enc2+`"}`), &cfg))
+		fst.Equal(t, plain, cfg.Password)
+	})
+
+	t.Run("no encrypted value", func(t *testing.T) {
+		var cfg config
+		fst.NoError(t, NewDefault().ParseBytes(".json", []byte(`{"Name":"demo"}`), &cfg))
+	})
+}
@@ -0,0 +1,30 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"github.com/fsgo/fsenv"
+)
+
+// SetEnv 设置应用的环境信息，配置的根目录、{fsenv.xxx} 以及 template 中的变量都会从 env 中读取，
+// 为 nil 时使用全局的 fsenv.Default
+func (c *Configure) SetEnv(env *fsenv.Attribute) {
+	c.env = env
+}
+
+// WithEnv 返回新的对象，并设置应用的环境信息
+func (c *Configure) WithEnv(env *fsenv.Attribute) *Configure {
+	c1 := c.Clone()
+	c1.env = env
+	return c1
+}
+
+// appEnv 返回当前使用的环境信息
+func (c *Configure) appEnv() *fsenv.Attribute {
+	if c != nil && c.env != nil {
+		return c.env
+	}
+	return fsenv.Default
+}
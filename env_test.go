@@ -0,0 +1,48 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fsgo/fsenv"
+	"github.com/fsgo/fst"
+)
+
+func TestConfigure_WithEnv(t *testing.T) {
+	root := t.TempDir()
+	fst.NoError(t, os.MkdirAll(filepath.Join(root, "conf"), 0755))
+	content := "# hook.template  Enable=true\n" +
+		`{"IDC": "{fsenv.IDC}", "ConfDir": "{fsenv.ConfDir}", "RunMode": "{{ .RunMode }}", "TplIDC": "{{ .IDC }}"}`
+	fst.NoError(t, os.WriteFile(filepath.Join(root, "conf", "app.json"), []byte(content), 0644))
+
+	env := fsenv.NewAttribute("demo", root)
+	env.SetIDC("gz")
+	env.SetRunMode(fsenv.ModeDebug)
+
+	conf := NewDefault().WithEnv(env)
+	fst.True(t, conf.Exists("app.json"))
+	fst.False(t, NewDefault().Exists("app.json"))
+
+	var got map[string]string
+	fst.NoError(t, conf.Parse("app.json", &got))
+	want := map[string]string{
+		"IDC":     "gz",
+		"ConfDir": env.ConfDir(),
+		"RunMode": fsenv.ModeDebug.String(),
+		"TplIDC":  "gz",
+	}
+	fst.Equal(t, want, got)
+
+	t.Run("SetEnv", func(t *testing.T) {
+		c := NewDefault()
+		c.SetEnv(env)
+		fst.True(t, c.Exists("app.json"))
+		c.SetEnv(nil)
+		fst.False(t, c.Exists("app.json"))
+	})
+}
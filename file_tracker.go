@@ -0,0 +1,120 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+type ctxKey uint8
+
+const (
+	ctxKeyFileTracker ctxKey = iota
+	ctxKeyTraceRecorder
+	ctxKeyHookStages
+)
+
+func withFileTracker(ctx context.Context, ft *fileTracker) context.Context {
+	return context.WithValue(ctx, ctxKeyFileTracker, ft)
+}
+
+func fileTrackerFromContext(ctx context.Context) *fileTracker {
+	if ctx == nil {
+		return nil
+	}
+	ft, _ := ctx.Value(ctxKeyFileTracker).(*fileTracker)
+	return ft
+}
+
+// trackFile 记录解析配置时读取的文件
+func trackFile(ctx context.Context, fp string) {
+	if ft := fileTrackerFromContext(ctx); ft != nil {
+		ft.addFile(fp)
+	}
+}
+
+// trackGlob 记录 include 等使用的文件匹配规则，以便发现新增和删除的文件
+func trackGlob(ctx context.Context, pattern string) {
+	if ft := fileTrackerFromContext(ctx); ft != nil {
+		ft.addGlob(pattern)
+	}
+}
+
+// fileTracker 记录一次解析过程中所依赖的所有文件
+type fileTracker struct {
+	// parent 可选，记录的文件也会同时记录到 parent 中
+	parent *fileTracker
+
+	files []string
+	globs []string
+	mux   sync.Mutex
+}
+
+func (ft *fileTracker) addFile(fp string) {
+	if ft.parent != nil {
+		ft.parent.addFile(fp)
+	}
+	ft.mux.Lock()
+	defer ft.mux.Unlock()
+	if !slices.Contains(ft.files, fp) {
+		ft.files = append(ft.files, fp)
+	}
+}
+
+func (ft *fileTracker) addGlob(pattern string) {
+	if ft.parent != nil {
+		ft.parent.addGlob(pattern)
+	}
+	ft.mux.Lock()
+	defer ft.mux.Unlock()
+	if !slices.Contains(ft.globs, pattern) {
+		ft.globs = append(ft.globs, pattern)
+	}
+}
+
+func (ft *fileTracker) fileList() []string {
+	ft.mux.Lock()
+	defer ft.mux.Unlock()
+	return append([]string{}, ft.files...)
+}
+
+func (ft *fileTracker) merge(other *fileTracker) {
+	for _, f := range other.fileList() {
+		ft.addFile(f)
+	}
+	other.mux.Lock()
+	globs := append([]string{}, other.globs...)
+	other.mux.Unlock()
+	for _, g := range globs {
+		ft.addGlob(g)
+	}
+}
+
+// snapshot 返回所有依赖文件的状态，用于判断是否有变化
+func (ft *fileTracker) snapshot(c *Configure) map[string]string {
+	ft.mux.Lock()
+	files := append([]string{}, ft.files...)
+	globs := append([]string{}, ft.globs...)
+	ft.mux.Unlock()
+
+	result := make(map[string]string, len(files)+len(globs))
+	for _, f := range files {
+		info, err := c.statFile(f)
+		if err != nil {
+			result[f] = "error:" + err.Error()
+			continue
+		}
+		result[f] = fmt.Sprintf("%d|%d|%s", info.Size(), info.ModTime().UnixNano(), info.Mode())
+	}
+	for _, g := range globs {
+		matches, _ := c.glob(g)
+		result["glob:"+g] = strings.Join(matches, "\n")
+	}
+	return result
+}
@@ -0,0 +1,228 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// FlagTag 用于设置命令行参数名称的 struct tag 名称，值为 "-" 时不绑定该字段
+const FlagTag = "flag"
+
+// FlagSetName 通用的覆盖配置的命令行参数名，如 -conf.set db.port=3307，可以重复使用多次
+const FlagSetName = "conf.set"
+
+// BindFlags 将配置结构体 obj 的字段绑定为命令行参数，参数名为 {prefix}.{父字段}.{字段} 的小写格式，
+// 如字段 DB.Port 对应参数 -db.port。若字段有 flag tag，则使用 tag 的值作为这一段的名称。
+// 同时会注册一个名为 {prefix}.conf.set 的参数，可以使用 key=value 的格式覆盖任意已绑定的字段。
+//
+// 应在 Parse 之后、fs.Parse 之前调用，这样命令行参数的优先级是最高的：配置文件 < 环境变量 < 命令行参数。
+// 参数的默认值为调用时字段的值，map 类型的字段不会绑定，
+// 递归类型（如 type Node struct{ Next *Node }）中，指向外层类型的字段不会绑定。
+// 嵌入的未导出结构体指针（如 *inner）为 nil 时，无法初始化，设置其字段会返回错误。
+func BindFlags(fs *flag.FlagSet, obj any, prefix string) error {
+	rv := reflect.ValueOf(obj)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("obj must be a non-nil pointer to struct, got %T", obj)
+	}
+	b := &flagBinder{
+		prefix:   prefix,
+		root:     rv.Elem,
+		values:   map[string]*flagValue{},
+		visiting: map[reflect.Type]bool{},
+	}
+	b.bindStruct(rv.Elem().Type(), prefix, "", nil)
+	names := make([]string, 0, len(b.values))
+	for name := range b.values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if fs.Lookup(name) != nil {
+			return fmt.Errorf("flag %q already defined", name)
+		}
+	}
+	setName := joinFlagName(prefix, FlagSetName)
+	if fs.Lookup(setName) != nil {
+		return fmt.Errorf("flag %q already defined", setName)
+	}
+	for _, name := range names {
+		v := b.values[name]
+		fs.Var(v, name, "override "+v.path)
+	}
+	fs.Var(&flagSetValue{binder: b}, setName, "override config value, format: key=value, can be used multiple times")
+	return nil
+}
+
+type flagBinder struct {
+	root   func() reflect.Value
+	values map[string]*flagValue // key 为参数名
+	prefix string
+
+	// visiting 正在绑定的 struct 类型，用于跳过递归类型，如 type Node struct{ Next *Node }
+	visiting map[reflect.Type]bool
+}
+
+func (b *flagBinder) bindStruct(rt reflect.Type, prefix string, path string, index []int) {
+	b.visiting[rt] = true
+	defer delete(b.visiting, rt)
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		if !sf.IsExported() && !sf.Anonymous {
+			continue
+		}
+		tag := sf.Tag.Get(FlagTag)
+		if tag == "-" {
+			continue
+		}
+		name := tag
+		if name == "" {
+			name = strings.ToLower(sf.Name)
+		}
+		idx := append(append([]int{}, index...), i)
+		fieldPath := sf.Name
+		if path != "" {
+			fieldPath = path + "." + sf.Name
+		}
+		ft := sf.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct && !reflect.PointerTo(ft).Implements(textUnmarshalerType) {
+			if b.visiting[ft] {
+				continue
+			}
+			if sf.Anonymous {
+				b.bindStruct(ft, prefix, path, idx)
+			} else {
+				b.bindStruct(ft, joinFlagName(prefix, name), fieldPath, idx)
+			}
+			continue
+		}
+		if !sf.IsExported() || ft.Kind() == reflect.Map {
+			continue
+		}
+		b.values[joinFlagName(prefix, name)] = &flagValue{
+			root:   b.root,
+			index:  idx,
+			path:   fieldPath,
+			kind:   ft.Kind(),
+			secret: DefaultRedactor.isSecretField(sf),
+		}
+	}
+}
+
+func joinFlagName(prefix string, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+var _ flag.Value = (*flagValue)(nil)
+
+// flagValue 一个绑定到配置字段的命令行参数
+type flagValue struct {
+	root  func() reflect.Value
+	path  string
+	index []int
+	kind  reflect.Kind
+
+	// secret 是否是敏感字段，若是，在帮助信息中不展示其值
+	secret bool
+}
+
+// field 返回对应的字段，当 alloc=true 时，会初始化路径中为 nil 的指针，
+// 路径中为 nil 的指针不能初始化（如嵌入的未导出结构体指针）时，返回 false
+func (fv *flagValue) field(alloc bool) (reflect.Value, bool) {
+	rv := fv.root()
+	for _, i := range fv.index {
+		if rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				if !alloc || !rv.CanSet() {
+					return reflect.Value{}, false
+				}
+				rv.Set(reflect.New(rv.Type().Elem()))
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(i)
+	}
+	return rv, true
+}
+
+func (fv *flagValue) String() string {
+	if fv.root == nil {
+		// flag 包会使用零值的 flag.Value 来判断默认值
+		return ""
+	}
+	rv, ok := fv.field(false)
+	if !ok {
+		return ""
+	}
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return ""
+		}
+		rv = rv.Elem()
+	}
+	if fv.secret && !rv.IsZero() {
+		return DefaultRedactor.mask()
+	}
+	return fmt.Sprint(rv.Interface())
+}
+
+func (fv *flagValue) Set(value string) error {
+	rv, ok := fv.field(true)
+	if !ok {
+		return fmt.Errorf("cannot set %s: embedded pointer to unexported struct is nil", fv.path)
+	}
+	if err := setFieldByString(rv, value); err != nil {
+		if fv.secret {
+			// 错误信息中可能包含敏感的值
+			return fmt.Errorf("invalid value for %s", rv.Type())
+		}
+		return err
+	}
+	return nil
+}
+
+func (fv *flagValue) IsBoolFlag() bool {
+	return fv.kind == reflect.Bool
+}
+
+var _ flag.Value = (*flagSetValue)(nil)
+
+// flagSetValue 通用的 -conf.set key=value 参数
+type flagSetValue struct {
+	binder *flagBinder
+	values []string
+}
+
+func (sv *flagSetValue) String() string {
+	return strings.Join(sv.values, ",")
+}
+
+func (sv *flagSetValue) Set(s string) error {
+	key, value, ok := strings.Cut(s, "=")
+	key = strings.TrimSpace(key)
+	if !ok || key == "" {
+		return errors.New("invalid format, expect key=value")
+	}
+	fv := sv.binder.values[joinFlagName(sv.binder.prefix, strings.ToLower(key))]
+	if fv == nil {
+		return fmt.Errorf("config key %q not found", key)
+	}
+	if err := fv.Set(value); err != nil {
+		return fmt.Errorf("set %q: %w", key, err)
+	}
+	sv.values = append(sv.values, s)
+	return nil
+}
@@ -0,0 +1,135 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"flag"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/fsgo/fst"
+)
+
+type testFlagDB struct {
+	Host    string
+	Port    int `env:"DB_PORT"`
+	Timeout time.Duration
+}
+
+type testFlagConfig struct {
+	Name   string `flag:"app-name"`
+	Debug  bool
+	Hosts  []string
+	Ignore string `flag:"-"`
+	Labels map[string]string
+	DB     testFlagDB
+	Backup *testFlagDB
+}
+
+func TestBindFlags(t *testing.T) {
+	t.Setenv("APP_DB_PORT", "3307")
+	c := NewDefault()
+	c.SetEnvOverride(&EnvOverride{Prefix: "APP"})
+	var cfg testFlagConfig
+	content := `{"Name":"file","DB":{"Host":"file-host","Port":3306}}`
+	fst.NoError(t, c.ParseBytes(".json", []byte(content), &cfg))
+	fst.Equal(t, 3307, cfg.DB.Port)
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fst.NoError(t, BindFlags(fs, &cfg, ""))
+	fst.NotNil(t, fs.Lookup("db.port"))
+	fst.Equal(t, "3307", fs.Lookup("db.port").DefValue)
+	fst.Nil(t, fs.Lookup("labels"))
+	fst.Nil(t, fs.Lookup("ignore"))
+
+	args := []string{
+		"-app-name=flag",
+		"-debug",
+		"-hosts=a,b",
+		"-db.port=3308",
+		"-conf.set", "db.timeout=2s",
+		"-conf.set", "Backup.Host=bak",
+	}
+	fst.NoError(t, fs.Parse(args))
+	want := testFlagConfig{
+		Name:   "flag",
+		Debug:  true,
+		Hosts:  []string{"a", "b"},
+		DB:     testFlagDB{Host: "file-host", Port: 3308, Timeout: 2 * time.Second},
+		Backup: &testFlagDB{Host: "bak"},
+	}
+	fst.Equal(t, want, cfg)
+
+	t.Run("errors", func(t *testing.T) {
+		fs2 := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs2.SetOutput(io.Discard)
+		fst.NoError(t, BindFlags(fs2, &cfg, "app"))
+		fst.NotNil(t, fs2.Lookup("app.db.port"))
+		fst.Error(t, fs2.Parse([]string{"-app.conf.set", "not_found=1"}))
+		fst.Error(t, fs2.Parse([]string{"-app.conf.set", "db.port"}))
+		fst.Error(t, fs2.Parse([]string{"-app.db.port=abc"}))
+
+		fst.Error(t, BindFlags(fs2, &cfg, "app"))
+		fst.Error(t, BindFlags(fs2, cfg, "app"))
+	})
+}
+
+type testFlagNode struct {
+	Name string
+	Next *testFlagNode
+	Meta struct {
+		Parent *testFlagNode
+		Owner  string
+	}
+}
+
+func TestBindFlags_recursive(t *testing.T) {
+	var node testFlagNode
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fst.NoError(t, BindFlags(fs, &node, ""))
+	fst.NotNil(t, fs.Lookup("name"))
+	fst.NotNil(t, fs.Lookup("meta.owner"))
+	fst.Nil(t, fs.Lookup("next.name"))
+	fst.Nil(t, fs.Lookup("meta.parent.name"))
+
+	fst.NoError(t, fs.Parse([]string{"-name=n1", "-meta.owner=o1"}))
+	fst.Equal(t, "n1", node.Name)
+	fst.Equal(t, "o1", node.Meta.Owner)
+}
+
+type testFlagInner struct {
+	X int
+}
+
+func TestBindFlags_unexportedEmbedded(t *testing.T) {
+	type config struct {
+		*testFlagInner
+		Name string
+	}
+	newFlagSet := func(cfg *config) *flag.FlagSet {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		fst.NoError(t, BindFlags(fs, cfg, ""))
+		return fs
+	}
+
+	t.Run("nil", func(t *testing.T) {
+		var cfg config
+		fs := newFlagSet(&cfg)
+		fst.Error(t, fs.Parse([]string{"-x=3"}))
+		fst.Nil(t, cfg.testFlagInner)
+	})
+
+	t.Run("not nil", func(t *testing.T) {
+		cfg := config{testFlagInner: &testFlagInner{}}
+		fs := newFlagSet(&cfg)
+		fst.NoError(t, fs.Parse([]string{"-x=3", "-name=n1"}))
+		fst.Equal(t, 3, cfg.X)
+		fst.Equal(t, "n1", cfg.Name)
+	})
+}
@@ -0,0 +1,80 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// WithFS 返回新的对象，使用 fsys 读取配置文件，如 embed.FS、fstest.MapFS，
+// 包括查找文件后缀、template 的 include 以及 Watch 等所有读取文件的地方。
+//
+// 此时配置文件名称是 fsys 中的路径（使用 "/" 分隔，如 "conf/app.json"），
+// 不再拼接 fsenv.ConfDir()，以 "/" 开头的名称同样相对于 fsys 的根目录。
+// fsys 为 nil 时，使用本地文件系统。
+func (c *Configure) WithFS(fsys fs.FS) *Configure {
+	c1 := c.Clone()
+	c1.fsys = fsys
+	return c1
+}
+
+// fsPath 将配置名称转换为 fsys 中的路径
+func (c *Configure) fsPath(name string) (string, error) {
+	name = path.Clean(filepath.ToSlash(name))
+	name = strings.TrimPrefix(name, "/")
+	if name == "" {
+		name = "."
+	}
+	if !fs.ValidPath(name) {
+		return "", fmt.Errorf("invalid path %q for fs.FS", name)
+	}
+	return name, nil
+}
+
+func (c *Configure) statFile(name string) (fs.FileInfo, error) {
+	if c.fsys != nil {
+		return fs.Stat(c.fsys, name)
+	}
+	return os.Stat(name)
+}
+
+func (c *Configure) readFile(name string) ([]byte, error) {
+	if c.fsys != nil {
+		return fs.ReadFile(c.fsys, name)
+	}
+	return os.ReadFile(name)
+}
+
+func (c *Configure) glob(pattern string) ([]string, error) {
+	if c.fsys != nil {
+		return fs.Glob(c.fsys, pattern)
+	}
+	return filepath.Glob(pattern)
+}
+
+// fileExists 判断文件是否存在，且不是目录
+func (c *Configure) fileExists(name string) bool {
+	info, err := c.statFile(name)
+	return err == nil && !info.IsDir()
+}
+
+// resolvePath 返回 name 相对于文件 base 的路径，name 为绝对路径时直接返回
+func (c *Configure) resolvePath(base string, name string) (string, error) {
+	if c.fsys == nil {
+		if filepath.IsAbs(name) {
+			return name, nil
+		}
+		return filepath.Join(filepath.Dir(base), name), nil
+	}
+	if strings.HasPrefix(name, "/") {
+		return c.fsPath(name)
+	}
+	return c.fsPath(path.Join(path.Dir(base), name))
+}
@@ -0,0 +1,84 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/fsgo/fst"
+)
+
+func TestConfigure_WithFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"conf/app.json": &fstest.MapFile{
+			Data: []byte("# hook.template  Enable=true\n{\n  \"A\": \"a\",\n{{ include \"sub/*.json\" }}\n  \"C\": \"c\"\n}\n"),
+		},
+		"conf/sub/b.json": &fstest.MapFile{
+			Data: []byte("  \"B\": \"b\",\n"),
+		},
+		// 和配置文件同名的目录
+		"conf/db.json":      &fstest.MapFile{Data: []byte(`{"A":"db"}`)},
+		"conf/db/x.json":    &fstest.MapFile{Data: []byte(`{}`)},
+		"conf/bad.json":     &fstest.MapFile{Data: []byte("{\n  \"A\": a\n}\n")},
+		"conf/inc_abs.json": &fstest.MapFile{Data: []byte("# hook.template  Enable=true\n{\n{{ include \"/conf/sub/b.json\" }}\n  \"A\": \"x\"\n}\n")},
+	}
+	conf := NewDefault().WithFS(fsys)
+
+	type config struct {
+		A string
+		B string
+		C string
+	}
+
+	t.Run("include", func(t *testing.T) {
+		var cfg config
+		fst.NoError(t, conf.Parse("conf/app.json", &cfg))
+		fst.Equal(t, config{A: "a", B: "b", C: "c"}, cfg)
+	})
+
+	t.Run("include abs", func(t *testing.T) {
+		var cfg config
+		fst.NoError(t, conf.Parse("/conf/inc_abs.json", &cfg))
+		fst.Equal(t, config{A: "x", B: "b"}, cfg)
+	})
+
+	t.Run("without ext", func(t *testing.T) {
+		var cfg config
+		fst.NoError(t, conf.Parse("conf/app", &cfg))
+		fst.Equal(t, "a", cfg.A)
+	})
+
+	t.Run("same name dir", func(t *testing.T) {
+		var cfg config
+		fst.NoError(t, conf.Parse("./conf/db", &cfg))
+		fst.Equal(t, "db", cfg.A)
+	})
+
+	t.Run("exists", func(t *testing.T) {
+		fst.True(t, conf.Exists("conf/app.json"))
+		fst.True(t, conf.Exists("conf/app"))
+		// conf.d 目录
+		fst.True(t, conf.Exists("conf/sub"))
+		fst.False(t, conf.Exists("conf/not_found"))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		var cfg config
+		fst.Error(t, conf.Parse("conf/not_found.json", &cfg))
+		fst.Error(t, conf.Parse("../conf/app.json", &cfg))
+	})
+
+	t.Run("parse error", func(t *testing.T) {
+		var cfg config
+		err := conf.Parse("conf/bad.json", &cfg)
+		fst.Error(t, err)
+		fst.Contains(t, err.Error(), "conf/bad.json:2:")
+	})
+
+	t.Run("not affect origin", func(t *testing.T) {
+		fst.False(t, NewDefault().Exists("conf/sub/b.json"))
+	})
+}
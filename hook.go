@@ -8,8 +8,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
-	"github.com/fsgo/fsconf/internal/hook"
+	"github.com/fsgo/fsconf/internal/parser"
 )
 
 // Hook 辅助类，在执行解析前，会先会配置的内容进行解析处理
@@ -30,28 +32,150 @@ type HookParam struct {
 	Content   []byte     // 文件内容
 }
 
-var defaultHooks hooks = []Hook{
+// DefaultHookPriority 未实现 PriorityHook 接口的 Hook 的优先级
+//
+// 内置 Hook 的优先级：template=100，osenv=200，fsenv=300，secret=400，enc=500
+const DefaultHookPriority = 1000
+
+// PriorityHook 声明了执行优先级的 Hook，值越小越先执行，优先级相同时按照注册的顺序执行
+type PriorityHook interface {
+	Hook
+	Priority() int
+}
+
+// FileExtHook 只对特定文件后缀生效的 Hook
+type FileExtHook interface {
+	Hook
+	// FileExts 生效的文件后缀，如 []string{".json",".toml"}，为空时对所有文件生效
+	FileExts() []string
+}
+
+var defaultHooks = newHooks(
 	&hookTemplate{},
-	newHook("osenv", hook.OsEnvVars),
+	&hookOsEnv{},
 	&hookFsEnv{},
+	&hookSecret{},
+	&hookEnc{},
+)
+
+func newHooks(hs ...Hook) hooks {
+	var result hooks
+	for _, h := range hs {
+		if err := result.Add(h); err != nil {
+			panic(err)
+		}
+	}
+	return result
 }
 
-type hooks []Hook
+type hookEntry struct {
+	Hook
+	priority int
+	exts     []string
+}
 
-func (hs *hooks) Add(h Hook) error {
+func newHookEntry(h Hook, priority int) *hookEntry {
+	e := &hookEntry{
+		Hook:     h,
+		priority: priority,
+	}
+	if fh, ok := h.(FileExtHook); ok {
+		e.exts = fh.FileExts()
+	}
+	return e
+}
+
+func (e *hookEntry) matchExt(fileExt string) bool {
+	return len(e.exts) == 0 || slices.Contains(e.exts, fileExt)
+}
+
+// hooks 所有的 Hook，总是按照优先级排好序的
+type hooks []*hookEntry
+
+func (hs hooks) check(h Hook) error {
 	if len(h.Name()) == 0 {
 		return errors.New("hook.Name is empty, not allow")
 	}
+	if hs.index(h.Name()) >= 0 {
+		return fmt.Errorf("hook=%q already exists", h.Name())
+	}
+	return nil
+}
 
-	for _, h1 := range *hs {
-		if h.Name() == h1.Name() {
-			return fmt.Errorf("hook=%q already exists", h.Name())
+func (hs hooks) index(name string) int {
+	for i, h := range hs {
+		if h.Name() == name {
+			return i
 		}
 	}
-	*hs = append(*hs, h)
+	return -1
+}
+
+// Add 按照优先级添加 Hook，排在所有优先级相同的 Hook 之后
+func (hs *hooks) Add(h Hook) error {
+	if err := hs.check(h); err != nil {
+		return err
+	}
+	priority := DefaultHookPriority
+	if ph, ok := h.(PriorityHook); ok {
+		priority = ph.Priority()
+	}
+	idx := len(*hs)
+	for i, e := range *hs {
+		if e.priority > priority {
+			idx = i
+			break
+		}
+	}
+	*hs = slices.Insert(*hs, idx, newHookEntry(h, priority))
+	return nil
+}
+
+// AddAt 将 Hook 添加到名为 target 的 Hook 之前或者之后，并使用 target 的优先级
+func (hs *hooks) AddAt(target string, after bool, h Hook) error {
+	if err := hs.check(h); err != nil {
+		return err
+	}
+	idx := hs.index(target)
+	if idx < 0 {
+		return fmt.Errorf("hook=%q not found", target)
+	}
+	priority := (*hs)[idx].priority
+	if after {
+		idx++
+	}
+	*hs = slices.Insert(*hs, idx, newHookEntry(h, priority))
+	return nil
+}
+
+// Remove 删除名为 name 的 Hook，返回是否存在
+func (hs *hooks) Remove(name string) bool {
+	idx := hs.index(name)
+	if idx < 0 {
+		return false
+	}
+	*hs = slices.Delete(*hs, idx, idx+1)
+	return true
+}
+
+// Replace 使用 h 替换同名的 Hook，执行位置和优先级保持不变
+func (hs *hooks) Replace(h Hook) error {
+	idx := hs.index(h.Name())
+	if idx < 0 {
+		return fmt.Errorf("hook=%q not found", h.Name())
+	}
+	(*hs)[idx] = newHookEntry(h, (*hs)[idx].priority)
 	return nil
 }
 
+func (hs hooks) list() []Hook {
+	result := make([]Hook, 0, len(hs))
+	for _, e := range hs {
+		result = append(result, e.Hook)
+	}
+	return result
+}
+
 func (hs hooks) Execute(ctx context.Context, p *HookParam) (output []byte, err error) {
 	if len(hs) == 0 {
 		return p.Content, nil
@@ -62,32 +186,51 @@ func (hs hooks) Execute(ctx context.Context, p *HookParam) (output []byte, err e
 		return nil, fmt.Errorf("copy config content failed, want=%d copied=%d", len(input), n)
 	}
 
+	disabled := disabledHooks(input)
+	stages := hookStagesFromContext(ctx)
 	for _, hk := range hs {
+		if !hk.matchExt(p.FileExt) || disabled[hk.Name()] {
+			continue
+		}
 		p.Content = content
 		content, err = hk.Execute(ctx, p)
 		if err != nil {
 			return nil, fmt.Errorf("hook=%q has error:%w", hk.Name(), err)
 		}
+		if stages != nil {
+			stages.add(hk.Name(), p.Content, content)
+		}
 	}
 	return content, err
 }
 
-type hookTpl struct {
-	fn   hook.Fn
-	name string
-}
+var hookDirectivePrefix = "fsconf "
 
-func (h *hookTpl) Name() string {
-	return h.name
-}
-
-func (h *hookTpl) Execute(_ context.Context, p *HookParam) (output []byte, err error) {
-	return h.fn(p.ConfPath, p.Content)
-}
-
-func newHook(name string, fn hook.Fn) Hook {
-	return &hookTpl{
-		name: name,
-		fn:   fn,
+// disabledHooks 读取文件头部声明的不执行的 Hook，多个使用逗号分隔：
+//
+//	# fsconf hooks=-osenv,-template
+func disabledHooks(content []byte) map[string]bool {
+	var result map[string]bool
+	for _, cmt := range parser.HeadComments(content) {
+		if !strings.HasPrefix(cmt, hookDirectivePrefix) {
+			continue
+		}
+		for _, item := range strings.Fields(cmt[len(hookDirectivePrefix):]) {
+			k, v, ok := strings.Cut(item, "=")
+			if !ok || k != "hooks" {
+				continue
+			}
+			for _, name := range strings.Split(v, ",") {
+				name, ok = strings.CutPrefix(name, "-")
+				if !ok || name == "" {
+					continue
+				}
+				if result == nil {
+					result = map[string]bool{}
+				}
+				result[name] = true
+			}
+		}
 	}
+	return result
 }
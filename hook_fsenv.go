@@ -8,8 +8,6 @@ import (
 	"context"
 	"fmt"
 	"regexp"
-
-	"github.com/fsgo/fsenv"
 )
 
 var _ Hook = (*hookFsEnv)(nil)
@@ -20,6 +18,10 @@ func (f *hookFsEnv) Name() string {
 	return "fsenv"
 }
 
+func (f *hookFsEnv) Priority() int {
+	return 300
+}
+
 // 模板变量格式：{fsenv.变量名}
 var fsEnvVarReg = regexp.MustCompile(`\{fsenv\.([A-Za-z0-9_]+)\}`)
 
@@ -40,23 +42,24 @@ func (f *hookFsEnv) Execute(ctx context.Context, p *HookParam) (output []byte, e
 	return contentNew, err
 }
 
-func (f *hookFsEnv) getValue(key string, _ *Configure) (string, error) {
+func (f *hookFsEnv) getValue(key string, c *Configure) (string, error) {
+	env := c.appEnv()
 	var value string
 	switch key {
 	case "RootDir":
-		value = fsenv.RootDir()
+		value = env.RootDir()
 	case "IDC":
-		value = fsenv.IDC()
+		value = env.IDC()
 	case "DataDir":
-		value = fsenv.DataDir()
+		value = env.DataDir()
 	case "ConfDir":
-		value = fsenv.ConfDir()
+		value = env.ConfDir()
 	case "TempDir":
-		value = fsenv.TempDir()
+		value = env.TempDir()
 	case "LogDir":
-		value = fsenv.LogDir()
+		value = env.LogDir()
 	case "RunMode":
-		value = fsenv.RunMode().String()
+		value = env.RunMode().String()
 	default:
 		return "", fmt.Errorf("key=%q not support", key)
 	}
@@ -0,0 +1,169 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fsgo/fsconf/internal/hook"
+	"github.com/fsgo/fsconf/internal/parser"
+)
+
+var _ Hook = (*hookOsEnv)(nil)
+
+// hookOsEnv 将配置中的 {osenv.xxx} 替换为环境变量的值，
+// 除了 os.Getenv，还可以从 SetEnvFiles 或者文件头部声明的 .env 文件中读取（template hook 中的 env、osenv 函数也一样）：
+//
+//	# hook.osenv  EnvFile=.env,.env.local
+type hookOsEnv struct{}
+
+func (h *hookOsEnv) Name() string {
+	return "osenv"
+}
+
+func (h *hookOsEnv) Priority() int {
+	return 200
+}
+
+var hookOsEnvPrefix = "hook.osenv "
+
+func (h *hookOsEnv) Execute(ctx context.Context, p *HookParam) (output []byte, err error) {
+	c := p.Configure
+	if c == nil {
+		c = Default()
+	}
+	lookup, err := c.confEnvLookup(ctx, p.ConfPath, p.Content)
+	if err != nil {
+		return nil, err
+	}
+	return hook.NewOsEnvVars(lookup)(p.ConfPath, p.Content)
+}
+
+// confEnvLookup 返回配置文件 confPath 中查找环境变量的方法，
+// 会读取 SetEnvFiles 以及 content 头部声明的 .env 文件
+func (c *Configure) confEnvLookup(ctx context.Context, confPath string, content []byte) (func(key string) (string, bool), error) {
+	files, err := c.envFilePaths()
+	if err != nil {
+		return nil, err
+	}
+	headerFiles := headerEnvFiles(content)
+	if len(headerFiles) > 0 && confPath == "" {
+		return nil, errors.New("p.ConfPath is empty cannot use EnvFile")
+	}
+	for _, name := range headerFiles {
+		fp, err := c.resolvePath(confPath, name)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, fp)
+	}
+	return c.envLookup(ctx, files)
+}
+
+// headerEnvFiles 读取文件头部声明的 .env 文件
+func headerEnvFiles(content []byte) []string {
+	var files []string
+	for _, cmt := range parser.HeadComments(content) {
+		if !strings.HasPrefix(cmt, hookOsEnvPrefix) {
+			continue
+		}
+		for _, item := range strings.Fields(cmt[len(hookOsEnvPrefix):]) {
+			k, v, ok := strings.Cut(item, "=")
+			if !ok || k != "EnvFile" {
+				continue
+			}
+			for _, f := range strings.Split(v, ",") {
+				if f != "" {
+					files = append(files, f)
+				}
+			}
+		}
+	}
+	return files
+}
+
+// envLookup 返回查找环境变量的方法，优先使用 os 的环境变量，不存在时再从 .env 文件 files 中查找
+func (c *Configure) envLookup(ctx context.Context, files []string) (func(key string) (string, bool), error) {
+	if len(files) == 0 {
+		return os.LookupEnv, nil
+	}
+	vars := map[string]string{}
+	for _, fp := range files {
+		if err := c.loadEnvFile(ctx, fp, vars); err != nil {
+			return nil, err
+		}
+	}
+	lookup := func(key string) (string, bool) {
+		if v, ok := os.LookupEnv(key); ok {
+			return v, true
+		}
+		v, ok := vars[key]
+		return v, ok
+	}
+	return lookup, nil
+}
+
+// loadEnvFile 读取 .env 文件，文件不存在时跳过，后读取的文件会覆盖前面的同名变量
+func (c *Configure) loadEnvFile(ctx context.Context, fp string, vars map[string]string) error {
+	// 即使文件不存在也记录下来，以便 Watch 能够发现新增的文件
+	trackFile(ctx, fp)
+	bf, err := c.readFile(fp)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	kv, err := parser.ParseDotEnv(bf)
+	if err != nil {
+		return fmt.Errorf("parse env file %q: %w", fp, err)
+	}
+	for k, v := range kv {
+		vars[k] = v
+	}
+	return nil
+}
+
+// SetEnvFiles 设置 osenv Hook 额外读取变量的 .env 文件，文件不存在时会跳过，
+// 当环境变量不存在时，才会使用 .env 文件中的值，多个文件中的同名变量，后面的优先。
+// {secret.env:xxx} 以及 template hook 中的 env、osenv 函数也会读取这些文件。
+//
+// 和 Parse 的配置名称一样，相对路径是相对于配置的根目录（fsenv.ConfDir()）的，
+// 以 "./"、"../" 开头的是相对于当前工作目录，使用 WithFS 时，是相对于 fsys 的根目录。
+func (c *Configure) SetEnvFiles(files ...string) {
+	c.envFiles = files
+}
+
+// envFilePaths 返回 SetEnvFiles 设置的 .env 文件的实际路径
+func (c *Configure) envFilePaths() ([]string, error) {
+	result := make([]string, 0, len(c.envFiles))
+	for _, name := range c.envFiles {
+		fp, err := c.envFilePath(name)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, fp)
+	}
+	return result, nil
+}
+
+func (c *Configure) envFilePath(name string) (string, error) {
+	if c.fsys != nil {
+		return c.fsPath(name)
+	}
+	if filepath.IsAbs(name) {
+		return name, nil
+	}
+	if strings.HasPrefix(name, "./") || strings.HasPrefix(name, "../") {
+		return filepath.Abs(name)
+	}
+	return filepath.Join(c.appEnv().ConfDir(), name), nil
+}
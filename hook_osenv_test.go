@@ -0,0 +1,58 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"testing"
+
+	"github.com/fsgo/fst"
+)
+
+func TestHookOsEnv_envFile(t *testing.T) {
+	type config struct {
+		Name string
+		Port int
+		Host string
+	}
+
+	t.Run("header", func(t *testing.T) {
+		var cfg config
+		fst.NoError(t, NewDefault().Parse("dotenv/app.json", &cfg))
+		fst.Equal(t, config{Name: "demo", Port: 9090, Host: "127.0.0.1"}, cfg)
+	})
+
+	t.Run("os env first", func(t *testing.T) {
+		t.Setenv("FSCONF_DOTENV_NAME", "from-os")
+		var cfg config
+		fst.NoError(t, NewDefault().Parse("dotenv/app.json", &cfg))
+		fst.Equal(t, "from-os", cfg.Name)
+	})
+
+	t.Run("configure", func(t *testing.T) {
+		conf := NewDefault()
+		conf.SetEnvFiles("dotenv/app.env", "dotenv/not_exists.env")
+		var cfg config
+		content := `{"Name":"{osenv.FSCONF_DOTENV_NAME}","Port":{osenv.FSCONF_DOTENV_PORT}}`
+		fst.NoError(t, conf.ParseBytes(".json", []byte(content), &cfg))
+		fst.Equal(t, config{Name: "demo", Port: 8080}, cfg)
+
+		// 以 "./" 开头的是相对于当前工作目录
+		conf.SetEnvFiles("./testdata/conf/dotenv/app.env")
+		var cfg2 config
+		fst.NoError(t, conf.ParseBytes(".json", []byte(content), &cfg2))
+		fst.Equal(t, config{Name: "demo", Port: 8080}, cfg2)
+
+		// 不再相对于当前工作目录
+		conf.SetEnvFiles("testdata/conf/dotenv/app.env")
+		var cfg3 config
+		fst.Error(t, conf.ParseBytes(".json", []byte(content), &cfg3))
+	})
+
+	t.Run("parse .env", func(t *testing.T) {
+		var got map[string]string
+		fst.NoError(t, NewDefault().Parse("dotenv/app.local.env", &got))
+		fst.Equal(t, map[string]string{"FSCONF_DOTENV_PORT": "9090"}, got)
+	})
+}
@@ -0,0 +1,98 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"regexp"
+	"slices"
+)
+
+var _ Hook = (*hookSecret)(nil)
+
+// hookSecret 将配置中的 {secret.provider:key} 替换为 SecretProvider 读取到的值
+type hookSecret struct{}
+
+func (h *hookSecret) Name() string {
+	return "secret"
+}
+
+func (h *hookSecret) Priority() int {
+	return 400
+}
+
+var secretNameReg = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// 模板变量格式：{secret.名称:key}，如 {secret.env:DB_PASS}、{secret.file:/run/secrets/db}
+var secretVarReg = regexp.MustCompile(`\{secret\.([A-Za-z0-9_-]+):([^}]+)\}`)
+
+func (h *hookSecret) Execute(ctx context.Context, p *HookParam) (output []byte, err error) {
+	if p.Configure == nil {
+		return nil, errors.New("secret hook requires HookParam.Configure")
+	}
+	return replaceValueVars(p.Content, secretVarReg, p.FileExt, func(m [][]byte) (string, error) {
+		return p.Configure.getSecret(ctx, string(m[1]), string(m[2]))
+	})
+}
+
+// quoteEscapeExts 双引号字符串中可以使用 \"、\\、\n、\uXXXX 等转义的文件格式
+var quoteEscapeExts = []string{".json", ".jsonc", ".json5", ".toml", ".yaml", ".yml"}
+
+// replaceValueVars 将 content 中匹配 reg 的内容替换为 fn 返回的值（如密码等敏感信息），
+// 当 fileExt 是 quoteEscapeExts 中的格式，且匹配的内容在同一行的双引号字符串中时，
+// 会对值中的 "、\、换行等进行转义，以避免破坏配置的格式，其他情况会原样替换
+func replaceValueVars(content []byte, reg *regexp.Regexp, fileExt string, fn func(m [][]byte) (string, error)) ([]byte, error) {
+	locs := reg.FindAllSubmatchIndex(content, -1)
+	if len(locs) == 0 {
+		return content, nil
+	}
+	escape := slices.Contains(quoteEscapeExts, fileExt)
+	var buf bytes.Buffer
+	buf.Grow(len(content))
+	last := 0
+	for _, loc := range locs {
+		m := make([][]byte, len(loc)/2)
+		for i := range m {
+			if loc[2*i] >= 0 {
+				m[i] = content[loc[2*i]:loc[2*i+1]]
+			}
+		}
+		val, err := fn(m)
+		if err != nil {
+			return nil, err
+		}
+		if escape && inDoubleQuote(content, loc[0]) {
+			quoted := tomlQuote(val)
+			val = quoted[1 : len(quoted)-1]
+		}
+		buf.Write(content[last:loc[0]])
+		buf.WriteString(val)
+		last = loc[1]
+	}
+	buf.Write(content[last:])
+	return buf.Bytes(), nil
+}
+
+// inDoubleQuote 判断 content 中 offset 的位置，是否在同一行的双引号字符串中
+func inDoubleQuote(content []byte, offset int) bool {
+	start := bytes.LastIndexByte(content[:offset], '\n') + 1
+	var quote byte
+	for i := start; i < offset; i++ {
+		ch := content[i]
+		switch {
+		case quote == 0:
+			if ch == '"' || ch == '\'' {
+				quote = ch
+			}
+		case ch == '\\' && quote == '"':
+			i++
+		case ch == quote:
+			quote = 0
+		}
+	}
+	return quote == '"'
+}
@@ -11,15 +11,12 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
 	"text/template"
 	"time"
 
-	"github.com/fsgo/fsenv"
-
 	"github.com/fsgo/fsconf/internal/parser"
 	"github.com/fsgo/fsconf/internal/xcache"
 )
@@ -32,6 +29,10 @@ func (h *hookTemplate) Name() string {
 	return "template"
 }
 
+func (h *hookTemplate) Priority() int {
+	return 100
+}
+
 var hookTplPrefix = "hook.template "
 
 func (h *hookTemplate) Execute(ctx context.Context, hp *HookParam) (output []byte, err error) {
@@ -68,39 +69,51 @@ func (h *hookTemplate) exec(ctx context.Context, hp *HookParam, tp map[string]st
 		right = v
 	}
 	tmpl.Delims(left, right)
+	tmpl.Funcs(templateFuncs)
+	envLookup := h.fnEnvLookup(ctx, hp)
 	tmpl.Funcs(map[string]any{
+		"osenv": func(name string) (string, error) {
+			lookup, err := envLookup()
+			if err != nil {
+				return "", err
+			}
+			v, _ := lookup(name)
+			return v, nil
+		},
+		"env": func(name string, fallback ...string) (string, error) {
+			lookup, err := envLookup()
+			if err != nil {
+				return "", err
+			}
+			return tplEnv(lookup, name, fallback...), nil
+		},
 		"include": func(name string) (string, error) {
 			return h.fnInclude(ctx, name, hp, tp)
 		},
 		"fetch": func(name string, args ...string) (string, error) {
 			return h.fnFetch(ctx, hp, tp, name, args)
 		},
-		"osenv": func(name string) string {
-			return os.Getenv(name)
-		},
-		"contains": func(s string, sub string) bool {
-			return strings.Contains(s, sub)
-		},
-		"prefix": func(s string, prefix string) bool {
-			return strings.HasPrefix(s, prefix)
-		},
-		"suffix": func(s string, suffix string) bool {
-			return strings.HasSuffix(s, suffix)
+		"readFile": func(name string) (string, error) {
+			return h.fnReadFile(ctx, name, hp)
 		},
 	})
+	if hp.Configure != nil && len(hp.Configure.tplFuncs) > 0 {
+		tmpl.Funcs(hp.Configure.tplFuncs)
+	}
 	tmpl, err = tmpl.Parse(string(hp.Content))
 	if err != nil {
 		return nil, err
 	}
 	buf := &bytes.Buffer{}
 
+	env := hp.Configure.appEnv()
 	data := map[string]string{
-		"IDC":         fsenv.IDC(),
-		"RootDir":     fsenv.RootDir(),
-		"ConfRootDir": fsenv.ConfDir(),
-		"LogRootDir":  fsenv.LogDir(),
-		"DataRootDir": fsenv.DataDir(),
-		"RunMode":     fsenv.RunMode().String(),
+		"IDC":         env.IDC(),
+		"RootDir":     env.RootDir(),
+		"ConfRootDir": env.ConfDir(),
+		"LogRootDir":  env.LogDir(),
+		"DataRootDir": env.DataDir(),
+		"RunMode":     env.RunMode().String(),
 	}
 
 	if err = tmpl.Execute(buf, data); err != nil {
@@ -121,17 +134,20 @@ func (h *hookTemplate) fnInclude(ctx context.Context, name string, p *HookParam,
 	if len(p.ConfPath) == 0 {
 		return "", errors.New("p.ConfPath is empty cannot use include")
 	}
-	var fp string
-	if filepath.IsAbs(name) {
-		fp = name
-	} else {
-		fp = filepath.Join(filepath.Dir(p.ConfPath), name)
+	conf := p.Configure
+	if conf == nil {
+		conf = Default()
+	}
+	fp, err := conf.resolvePath(p.ConfPath, name)
+	if err != nil {
+		return "", err
 	}
 
-	files, err := filepath.Glob(fp)
+	files, err := conf.glob(fp)
 	if err != nil {
 		return "", err
 	}
+	trackGlob(ctx, fp)
 	if len(files) == 0 {
 		if !h.pathHasMeta(name) {
 			return "", fmt.Errorf("include %q not found", name)
@@ -140,7 +156,8 @@ func (h *hookTemplate) fnInclude(ctx context.Context, name string, p *HookParam,
 	}
 	var buf bytes.Buffer
 	for _, f := range files {
-		body, err1 := os.ReadFile(f)
+		trackFile(ctx, f)
+		body, err1 := conf.readFile(f)
 		if err1 != nil {
 			return "", err1
 		}
@@ -160,7 +177,7 @@ func (h *hookTemplate) fnInclude(ctx context.Context, name string, p *HookParam,
 }
 
 func (h *hookTemplate) getXCache(p *HookParam) *xcache.FileCache {
-	dir := filepath.Join(fsenv.TempDir(), "fsconf_cache")
+	dir := filepath.Join(p.Configure.appEnv().TempDir(), "fsconf_cache")
 	return &xcache.FileCache{
 		Dir: dir,
 	}
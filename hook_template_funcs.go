@@ -0,0 +1,421 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/fsgo/fsconf/internal/tree"
+)
+
+// templateFuncs template hook 内置的、和配置文件无关的函数
+var templateFuncs = template.FuncMap{
+	// 字符串
+	"contains":   strings.Contains,
+	"prefix":     func(s string, prefix string) bool { return strings.HasPrefix(s, prefix) },
+	"suffix":     func(s string, suffix string) bool { return strings.HasSuffix(s, suffix) },
+	"lower":      strings.ToLower,
+	"upper":      strings.ToUpper,
+	"trim":       strings.TrimSpace,
+	"trimPrefix": func(prefix string, s string) string { return strings.TrimPrefix(s, prefix) },
+	"trimSuffix": func(suffix string, s string) string { return strings.TrimSuffix(s, suffix) },
+	"replace":    func(old string, new string, s string) string { return strings.ReplaceAll(s, old, new) },
+	"split":      func(sep string, s string) []string { return strings.Split(s, sep) },
+	"join":       tplJoin,
+
+	// 默认值和校验
+	"default":  tplDefault,
+	"required": tplRequired,
+
+	// 环境，env 和 osenv 需要读取 Configure 的 .env 文件，在 hookTemplate.exec 中注册
+	"hostname": os.Hostname,
+	"now":      time.Now,
+	"date":     func(layout string, t time.Time) string { return t.Format(layout) },
+
+	// 编码，toJSON 和 toTOML 输出的是对应格式的值，字符串会带上引号并转义
+	"toJSON": tplToJSON,
+	"toTOML": tplToTOML,
+	"b64enc": func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) },
+	"b64dec": tplB64Dec,
+	"sha256": func(s string) string {
+		sum := sha256.Sum256([]byte(s))
+		return hex.EncodeToString(sum[:])
+	},
+
+	// 整数运算
+	"add": func(a any, b any) (int64, error) { return tplIntOp(a, b, func(x, y int64) int64 { return x + y }) },
+	"sub": func(a any, b any) (int64, error) { return tplIntOp(a, b, func(x, y int64) int64 { return x - y }) },
+	"mul": func(a any, b any) (int64, error) { return tplIntOp(a, b, func(x, y int64) int64 { return x * y }) },
+	"div": tplDiv,
+	"mod": tplMod,
+	"max": func(a any, b any) (int64, error) { return tplIntOp(a, b, func(x, y int64) int64 { return max(x, y) }) },
+	"min": func(a any, b any) (int64, error) { return tplIntOp(a, b, func(x, y int64) int64 { return min(x, y) }) },
+
+	// 数据结构
+	"list": func(items ...any) []any { return items },
+	"dict": tplDict,
+}
+
+// tplDefault 当 v 为空值（如 ""、0、nil、空的数组）时，返回 d，用法：{{ .X | default "a" }}
+func tplDefault(d any, v ...any) any {
+	if len(v) == 0 || isEmptyValue(v[0]) {
+		return d
+	}
+	return v[0]
+}
+
+// tplRequired 当 v 为空值时返回错误，用法：{{ osenv "DB_HOST" | required "DB_HOST is required" }}
+func tplRequired(msg string, v any) (any, error) {
+	if isEmptyValue(v) {
+		return nil, errors.New(msg)
+	}
+	return v, nil
+}
+
+func isEmptyValue(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return rv.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return rv.IsNil()
+	}
+	return rv.IsZero()
+}
+
+// tplEnv 使用 lookup 读取环境变量，不存在或者为空时，返回 fallback
+func tplEnv(lookup func(key string) (string, bool), name string, fallback ...string) string {
+	if v, _ := lookup(name); v != "" {
+		return v
+	}
+	if len(fallback) > 0 {
+		return fallback[0]
+	}
+	return ""
+}
+
+// tplJoin 使用 sep 连接数组中的元素，用法：{{ list "a" "b" | join "," }}
+func tplJoin(sep string, list any) (string, error) {
+	rv := reflect.ValueOf(list)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return "", fmt.Errorf("join: expect a list, got %T", list)
+	}
+	items := make([]string, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		items[i] = fmt.Sprint(rv.Index(i).Interface())
+	}
+	return strings.Join(items, sep), nil
+}
+
+func tplB64Dec(s string) (string, error) {
+	bf, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return "", fmt.Errorf("b64dec: %w", err)
+	}
+	return string(bf), nil
+}
+
+func tplToJSON(v any) (string, error) {
+	bf, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(bf), nil
+}
+
+func tplToTOML(v any) (string, error) {
+	if t, ok := v.(time.Time); ok {
+		return t.Format(time.RFC3339Nano), nil
+	}
+	data, err := tree.Encode(v)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err = writeTOMLValue(&b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+var tomlBareKeyReg = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// writeTOMLValue 输出 TOML 格式的值，map 输出为 inline table
+func writeTOMLValue(b *strings.Builder, v any) error {
+	switch val := v.(type) {
+	case nil:
+		return errors.New("toTOML: nil value is not supported")
+	case string:
+		b.WriteString(tomlQuote(val))
+	case bool:
+		b.WriteString(strconv.FormatBool(val))
+	case float32, float64:
+		f := reflect.ValueOf(val).Float()
+		switch {
+		case math.IsNaN(f):
+			b.WriteString("nan")
+		case math.IsInf(f, 1):
+			b.WriteString("inf")
+		case math.IsInf(f, -1):
+			b.WriteString("-inf")
+		default:
+			s := strconv.FormatFloat(f, 'g', -1, 64)
+			if !strings.ContainsAny(s, ".eEn") {
+				s += ".0"
+			}
+			b.WriteString(s)
+		}
+	case []any:
+		b.WriteString("[")
+		for i, item := range val {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			if err := writeTOMLValue(b, item); err != nil {
+				return err
+			}
+		}
+		b.WriteString("]")
+	case map[string]any:
+		b.WriteString("{")
+		for i, k := range tree.SortedKeys(val) {
+			if i > 0 {
+				b.WriteString(",")
+			}
+			b.WriteString(" ")
+			if tomlBareKeyReg.MatchString(k) {
+				b.WriteString(k)
+			} else {
+				b.WriteString(tomlQuote(k))
+			}
+			b.WriteString(" = ")
+			if err := writeTOMLValue(b, val[k]); err != nil {
+				return err
+			}
+		}
+		if len(val) > 0 {
+			b.WriteString(" ")
+		}
+		b.WriteString("}")
+	default:
+		rv := reflect.ValueOf(v)
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			fmt.Fprint(b, v)
+		default:
+			return fmt.Errorf("toTOML: unsupported type %T", v)
+		}
+	}
+	return nil
+}
+
+// tomlQuote 输出 TOML 的基本字符串，只能使用 TOML 支持的转义
+func tomlQuote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// toInt64 将整数、整数形式的浮点数以及数字字符串转换为 int64
+func toInt64(v any) (int64, error) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u := rv.Uint()
+		if u > math.MaxInt64 {
+			return 0, fmt.Errorf("%d overflows int64", u)
+		}
+		return int64(u), nil
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if f != math.Trunc(f) || f > math.MaxInt64 || f < math.MinInt64 {
+			return 0, fmt.Errorf("%v is not an integer", f)
+		}
+		return int64(f), nil
+	case reflect.String:
+		return strconv.ParseInt(strings.TrimSpace(rv.String()), 10, 64)
+	}
+	return 0, fmt.Errorf("cannot convert %T to integer", v)
+}
+
+func tplIntOp(a any, b any, fn func(x, y int64) int64) (int64, error) {
+	x, err := toInt64(a)
+	if err != nil {
+		return 0, err
+	}
+	y, err := toInt64(b)
+	if err != nil {
+		return 0, err
+	}
+	return fn(x, y), nil
+}
+
+func tplDiv(a any, b any) (int64, error) {
+	x, y, err := tplIntPair(a, b)
+	if err != nil {
+		return 0, err
+	}
+	return x / y, nil
+}
+
+func tplMod(a any, b any) (int64, error) {
+	x, y, err := tplIntPair(a, b)
+	if err != nil {
+		return 0, err
+	}
+	return x % y, nil
+}
+
+// tplIntPair 转换除法的两个参数，除数不能为 0
+func tplIntPair(a any, b any) (int64, int64, error) {
+	x, err := toInt64(a)
+	if err != nil {
+		return 0, 0, err
+	}
+	y, err := toInt64(b)
+	if err != nil {
+		return 0, 0, err
+	}
+	if y == 0 {
+		return 0, 0, errors.New("division by zero")
+	}
+	return x, y, nil
+}
+
+// tplDict 使用 key、value 对创建 map，用法：{{ dict "host" "127.0.0.1" "port" 80 | toJSON }}
+func tplDict(kvs ...any) (map[string]any, error) {
+	if len(kvs)%2 != 0 {
+		return nil, errors.New("dict: expect even number of arguments")
+	}
+	result := make(map[string]any, len(kvs)/2)
+	for i := 0; i < len(kvs); i += 2 {
+		key, ok := kvs[i].(string)
+		if !ok {
+			return nil, fmt.Errorf("dict: key must be a string, got %T", kvs[i])
+		}
+		result[key] = kvs[i+1]
+	}
+	return result, nil
+}
+
+// fnEnvLookup 返回 template 中 env、osenv 函数查找环境变量的方法，
+// 和 osenv hook 一样，会读取 SetEnvFiles 以及当前配置文件头部声明的 .env 文件，
+// 只有在模板中使用时才会读取这些文件
+func (h *hookTemplate) fnEnvLookup(ctx context.Context, p *HookParam) func() (func(key string) (string, bool), error) {
+	var lookup func(key string) (string, bool)
+	return func() (func(key string) (string, bool), error) {
+		if lookup != nil {
+			return lookup, nil
+		}
+		conf := p.Configure
+		if conf == nil {
+			conf = Default()
+		}
+		fn, err := conf.confEnvLookup(ctx, p.ConfPath, p.Content)
+		if err != nil {
+			return nil, err
+		}
+		lookup = fn
+		return lookup, nil
+	}
+}
+
+// fnReadFile 读取文件的原始内容，name 是相对于当前配置文件的路径
+func (h *hookTemplate) fnReadFile(ctx context.Context, name string, p *HookParam) (string, error) {
+	if len(p.ConfPath) == 0 {
+		return "", errors.New("p.ConfPath is empty cannot use readFile")
+	}
+	conf := p.Configure
+	if conf == nil {
+		conf = Default()
+	}
+	fp, err := conf.resolvePath(p.ConfPath, name)
+	if err != nil {
+		return "", err
+	}
+	trackFile(ctx, fp)
+	bf, err := conf.readFile(fp)
+	if err != nil {
+		return "", err
+	}
+	return string(bf), nil
+}
+
+var tplFuncNameReg = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// RegisterTemplateFunc 注册 template hook 中可以使用的函数，若出现重名会注册失败，
+// 可以覆盖内置的函数。
+//
+// fn 必须是一个函数，返回 1 个值，或者 2 个值且第 2 个是 error，和 text/template 的 FuncMap 要求相同
+func (c *Configure) RegisterTemplateFunc(name string, fn any) error {
+	if !tplFuncNameReg.MatchString(name) {
+		return fmt.Errorf("invalid template func name %q", name)
+	}
+	ft := reflect.TypeOf(fn)
+	if ft == nil || ft.Kind() != reflect.Func {
+		return fmt.Errorf("template func %q is not a func", name)
+	}
+	switch {
+	case ft.NumOut() == 1:
+	case ft.NumOut() == 2 && ft.Out(1) == errorType:
+	default:
+		return fmt.Errorf("template func %q must return 1 value, or 2 values with the second of type error", name)
+	}
+	if _, has := c.tplFuncs[name]; has {
+		return fmt.Errorf("template func=%q already exists", name)
+	}
+	if c.tplFuncs == nil {
+		c.tplFuncs = map[string]any{}
+	}
+	c.tplFuncs[name] = fn
+	return nil
+}
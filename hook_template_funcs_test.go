@@ -0,0 +1,132 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/fsgo/fst"
+)
+
+func TestHookTemplate_funcs(t *testing.T) {
+	t.Setenv("FSCONF_TPL_A", "a1")
+	hostname, _ := os.Hostname()
+	render := func(conf *Configure, tpl string) (string, error) {
+		hp := &HookParam{
+			FileExt:   ".json",
+			Configure: conf,
+			Content:   []byte("# hook.template  Enable=true\n" + tpl),
+		}
+		out, err := (&hookTemplate{}).Execute(context.Background(), hp)
+		return strings.TrimPrefix(string(out), "# hook.template  Enable=true\n"), err
+	}
+	tests := []struct {
+		tpl     string
+		want    string
+		wantErr bool
+	}{
+		{tpl: `{{ "" | default "d" }}`, want: "d"},
+		{tpl: `{{ 0 | default 3 }}`, want: "3"},
+		{tpl: `{{ "v" | default "d" }}`, want: "v"},
+		{tpl: `{{ env "FSCONF_TPL_A" | required "A is required" }}`, want: "a1"},
+		{tpl: `{{ env "FSCONF_TPL_NOT_FOUND" | required "B is required" }}`, wantErr: true},
+		{tpl: `{{ env "FSCONF_TPL_NOT_FOUND" "fb" }}`, want: "fb"},
+		{tpl: `{{ osenv "FSCONF_TPL_A" }}`, want: "a1"},
+		{tpl: `{{ " Ab " | trim | lower }}-{{ "a" | upper }}`, want: "ab-A"},
+		{tpl: `{{ "v1.2" | trimPrefix "v" }}{{ "a.json" | trimSuffix ".json" }}{{ "a-b" | replace "-" "_" }}`, want: "1.2aa_b"},
+		{tpl: `{{ "a,b,c" | split "," | join ";" }}`, want: "a;b;c"},
+		{tpl: `{{ list 1 "b" | join "," }}`, want: "1,b"},
+		{tpl: `{{ join "," "abc" }}`, wantErr: true},
+		{tpl: `{{ "a\"b\n" | toJSON }}`, want: `"a\"b\n"`},
+		{tpl: `{{ dict "b" 1 "a" (list "x" "y") | toJSON }}`, want: `{"a":["x","y"],"b":1}`},
+		{tpl: `{{ "a\"b\\\n\x01" | toTOML }}`, want: `"a\"b\\\n\u0001"`},
+		{tpl: `{{ dict "b" 1.5 "a.b" (list "x" true) "c" (dict) | toTOML }}`, want: `{ "a.b" = ["x", true], b = 1.5, c = {} }`},
+		{tpl: `{{ dict "a" 1 "b" }}`, wantErr: true},
+		{tpl: `{{ dict 1 1 }}`, wantErr: true},
+		{tpl: `{{ "hello" | b64enc }}|{{ "aGVsbG8=" | b64dec }}`, want: "aGVsbG8=|hello"},
+		{tpl: `{{ "%%%" | b64dec }}`, wantErr: true},
+		{tpl: `{{ "abc" | sha256 }}`, want: "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+		{tpl: `{{ hostname }}`, want: hostname},
+		{tpl: `{{ now | date "2006" | len }}`, want: "4"},
+		{tpl: `{{ add 1 2 }},{{ sub 1 "3" }},{{ mul 2 3 }},{{ div 7 2 }},{{ mod 7 2 }},{{ max 1 2 }},{{ min 1 2 }}`, want: "3,-2,6,3,1,2,1"},
+		{tpl: `{{ div 1 0 }}`, wantErr: true},
+		{tpl: `{{ add 1.5 1 }}`, wantErr: true},
+		{tpl: `{{ add "a" 1 }}`, wantErr: true},
+		{tpl: `{{ contains "abc" "b" }} {{ prefix "abc" "a" }} {{ suffix "abc" "c" }}`, want: "true true true"},
+	}
+	conf := NewDefault()
+	for _, tt := range tests {
+		t.Run(tt.tpl, func(t *testing.T) {
+			got, err := render(conf, tt.tpl)
+			if tt.wantErr {
+				fst.Error(t, err)
+				return
+			}
+			fst.NoError(t, err)
+			fst.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("RegisterTemplateFunc", func(t *testing.T) {
+		c1 := conf.Clone()
+		fst.NoError(t, c1.RegisterTemplateFunc("repeat", strings.Repeat))
+		fst.NoError(t, c1.RegisterTemplateFunc("upper", func(s string) string { return "U" + s }))
+		fst.Error(t, c1.RegisterTemplateFunc("repeat", strings.Repeat))
+		fst.Error(t, c1.RegisterTemplateFunc("a-b", strings.Repeat))
+		fst.Error(t, c1.RegisterTemplateFunc("abc", "abc"))
+		fst.Error(t, c1.RegisterTemplateFunc("abc", func() {}))
+		fst.Error(t, c1.RegisterTemplateFunc("abc", func() (int, int) { return 1, 1 }))
+
+		got, err := render(c1, `{{ repeat "a" 3 }}{{ upper "b" }}`)
+		fst.NoError(t, err)
+		fst.Equal(t, "aaaUb", got)
+
+		_, err = render(conf, `{{ repeat "a" 3 }}`)
+		fst.Error(t, err)
+	})
+}
+
+func TestHookTemplate_readFile(t *testing.T) {
+	dir := t.TempDir()
+	fst.NoError(t, os.WriteFile(filepath.Join(dir, "cert.pem"), []byte("line1\n{{ x }}\n"), 0644))
+	fp := filepath.Join(dir, "app.json")
+	content := "# hook.template  Enable=true\n{\"Cert\": {{ readFile \"cert.pem\" | toJSON }}}\n"
+	fst.NoError(t, os.WriteFile(fp, []byte(content), 0644))
+
+	var got struct {
+		Cert string
+	}
+	fst.NoError(t, NewDefault().Parse(fp, &got))
+	fst.Equal(t, "line1\n{{ x }}\n", got.Cert)
+
+	fst.NoError(t, os.WriteFile(fp, []byte(strings.ReplaceAll(content, "cert.pem", "not_found.pem")), 0644))
+	fst.Error(t, NewDefault().Parse(fp, &got))
+}
+
+func TestHookTemplate_envFiles(t *testing.T) {
+	t.Setenv("FSCONF_TPL_OS", "os")
+	fsys := fstest.MapFS{
+		"conf/.env":       {Data: []byte("FSCONF_TPL_OS=file\nFSCONF_TPL_A=a\n")},
+		"conf/.env.local": {Data: []byte("FSCONF_TPL_B=b\n")},
+		"conf/app.json": {Data: []byte("# hook.template  Enable=true\n# hook.osenv  EnvFile=.env.local\n" +
+			`{"OS":"{{ osenv "FSCONF_TPL_OS" }}","A":"{{ env "FSCONF_TPL_A" "x" }}","B":"{{ osenv "FSCONF_TPL_B" }}","C":"{{ env "FSCONF_TPL_C" "c" }}"}`)},
+	}
+	c := NewDefault().WithFS(fsys)
+	c.SetEnvFiles("conf/.env")
+	var got map[string]string
+	fst.NoError(t, c.Parse("conf/app.json", &got))
+	fst.Equal(t, map[string]string{"OS": "os", "A": "a", "B": "b", "C": "c"}, got)
+
+	t.Run("without env files", func(t *testing.T) {
+		var got map[string]string
+		fst.NoError(t, NewDefault().WithFS(fsys).Parse("conf/app.json", &got))
+		fst.Equal(t, map[string]string{"OS": "os", "A": "x", "B": "b", "C": "c"}, got)
+	})
+}
@@ -10,6 +10,11 @@ import (
 	"errors"
 	"reflect"
 	"testing"
+
+	"github.com/fsgo/fst"
+
+	"github.com/fsgo/fsconf/internal/hook"
+	"github.com/fsgo/fsconf/internal/parser"
 )
 
 func TestHelpersExecute(t *testing.T) {
@@ -90,3 +95,119 @@ func TestHelpersExecute(t *testing.T) {
 		})
 	}
 }
+
+type testOrderHook struct {
+	name     string
+	priority int
+	exts     []string
+}
+
+func (h *testOrderHook) Name() string {
+	return h.name
+}
+
+func (h *testOrderHook) Priority() int {
+	return h.priority
+}
+
+func (h *testOrderHook) FileExts() []string {
+	return h.exts
+}
+
+func (h *testOrderHook) Execute(_ context.Context, p *HookParam) ([]byte, error) {
+	return append(p.Content, " "+h.name...), nil
+}
+
+func hookNames(hs hooks) []string {
+	names := make([]string, 0, len(hs))
+	for _, h := range hs {
+		names = append(names, h.Name())
+	}
+	return names
+}
+
+func TestHooksOrder(t *testing.T) {
+	fst.Equal(t, []string{"template", "osenv", "fsenv", "secret", "enc"}, hookNames(NewDefault().hooks)[:5])
+
+	conf := New()
+	conf.hooks = newHooks(&hookEnc{}, &hookSecret{}, &hookFsEnv{}, &hookOsEnv{}, &hookTemplate{})
+
+	fst.NoError(t, conf.RegisterHook(newHook("last", func(_ string, bf []byte) ([]byte, error) {
+		return bf, nil
+	})))
+	fst.NoError(t, conf.RegisterHook(&testOrderHook{name: "first", priority: 1}))
+	fst.NoError(t, conf.RegisterHook(&testOrderHook{name: "p200", priority: 200}))
+	fst.NoError(t, conf.RegisterHookBefore("osenv", &testOrderHook{name: "before_osenv", priority: 9999}))
+	fst.NoError(t, conf.RegisterHookAfter("template", &testOrderHook{name: "after_tpl"}))
+	fst.Error(t, conf.RegisterHookAfter("not_found", &testOrderHook{name: "x"}))
+	fst.Error(t, conf.RegisterHookAfter("osenv", &testOrderHook{name: "first"}))
+
+	want := []string{"first", "template", "after_tpl", "before_osenv", "osenv", "p200", "fsenv", "secret", "enc", "last"}
+	fst.Equal(t, want, hookNames(conf.hooks))
+
+	// 新注册的，优先级相同时，排在之后
+	fst.NoError(t, conf.RegisterHook(&testOrderHook{name: "p100", priority: 100}))
+	fst.Equal(t, "p100", conf.hooks[3].Name())
+
+	c1 := conf.Clone()
+	fst.NoError(t, c1.RegisterHook(&testOrderHook{name: "c1", priority: 1}))
+	fst.Len(t, conf.hooks, len(c1.hooks)-1)
+}
+
+func TestHooksExecute_filter(t *testing.T) {
+	hs := newHooks(
+		&testOrderHook{name: "a", priority: 2},
+		&testOrderHook{name: "b", priority: 1, exts: []string{".json"}},
+		&testOrderHook{name: "c", priority: 3},
+	)
+	t.Run("ext", func(t *testing.T) {
+		got, err := hs.Execute(context.Background(), &HookParam{FileExt: ".json", Content: []byte("x")})
+		fst.NoError(t, err)
+		fst.Equal(t, "x b a c", string(got))
+
+		got, err = hs.Execute(context.Background(), &HookParam{FileExt: ".toml", Content: []byte("x")})
+		fst.NoError(t, err)
+		fst.Equal(t, "x a c", string(got))
+	})
+	t.Run("header", func(t *testing.T) {
+		content := "# fsconf hooks=-a,-c,b\nx"
+		got, err := hs.Execute(context.Background(), &HookParam{FileExt: ".json", Content: []byte(content)})
+		fst.NoError(t, err)
+		fst.Equal(t, content+" b", string(got))
+	})
+}
+
+func TestParse_disableHook(t *testing.T) {
+	t.Setenv("fsconf_disable_hook", "abc")
+	conf := New()
+	fst.NoError(t, conf.RegisterParser(".json", parser.JSON))
+	fst.NoError(t, conf.RegisterHook(&hookOsEnv{}))
+	var got map[string]string
+	content := "# fsconf hooks=-osenv\n{\"A\":\"{osenv.fsconf_disable_hook}\"}"
+	fst.NoError(t, conf.ParseBytes(".json", []byte(content), &got))
+	fst.Equal(t, map[string]string{"A": "{osenv.fsconf_disable_hook}"}, got)
+
+	content = "{\"A\":\"{osenv.fsconf_disable_hook}\"}"
+	fst.NoError(t, conf.ParseBytes(".json", []byte(content), &got))
+	fst.Equal(t, map[string]string{"A": "abc"}, got)
+}
+
+type hookTpl struct {
+	fn   hook.Fn
+	name string
+}
+
+func (h *hookTpl) Name() string {
+	return h.name
+}
+
+func (h *hookTpl) Execute(_ context.Context, p *HookParam) (output []byte, err error) {
+	return h.fn(p.ConfPath, p.Content)
+}
+
+func newHook(name string, fn hook.Fn) Hook {
+	return &hookTpl{
+		name: name,
+		fn:   fn,
+	}
+}
@@ -14,26 +14,37 @@ import (
 var osEnvVarReg = regexp.MustCompile(`\{osenv\.([A-Za-z0-9_]+)(\|[^}]+)?\}`)
 
 // OsEnvVars 将配置文件中的 {env.xxx} 的内容，从环境变量中读取并替换
-func OsEnvVars(_ string, content []byte) ([]byte, error) {
-	contentNew := osEnvVarReg.ReplaceAllFunc(content, func(subStr []byte) []byte {
-		// 将 {osenv.xxx} 中的 xxx 部分取出
-		// 或者 将 {osenv.yyy|val} 中的 yyy|val 部分取出
+func OsEnvVars(cfPath string, content []byte) ([]byte, error) {
+	return NewOsEnvVars(os.LookupEnv)(cfPath, content)
+}
+
+// NewOsEnvVars 创建和 OsEnvVars 一样的 Fn，但是使用 lookup 查找变量的值
+func NewOsEnvVars(lookup func(key string) (string, bool)) Fn {
+	getenv := func(key string) string {
+		v, _ := lookup(key)
+		return v
+	}
+	return func(_ string, content []byte) ([]byte, error) {
+		contentNew := osEnvVarReg.ReplaceAllFunc(content, func(subStr []byte) []byte {
+			// 将 {osenv.xxx} 中的 xxx 部分取出
+			// 或者 将 {osenv.yyy|val} 中的 yyy|val 部分取出
 
-		keyWithDefaultVal := subStr[len("{osenv.") : len(subStr)-1] // eg: xxx 或者 yyy|val
-		idx := bytes.Index(keyWithDefaultVal, []byte("|"))
-		if idx > 0 {
-			// {osenv.变量名|默认值} 有默认值的格式
-			key := string(keyWithDefaultVal[:idx])  // eg: yyy
-			defaultVal := keyWithDefaultVal[idx+1:] // eg: val
-			envVal := os.Getenv(key)
-			if len(envVal) == 0 {
-				return defaultVal
+			keyWithDefaultVal := subStr[len("{osenv.") : len(subStr)-1] // eg: xxx 或者 yyy|val
+			idx := bytes.Index(keyWithDefaultVal, []byte("|"))
+			if idx > 0 {
+				// {osenv.变量名|默认值} 有默认值的格式
+				key := string(keyWithDefaultVal[:idx])  // eg: yyy
+				defaultVal := keyWithDefaultVal[idx+1:] // eg: val
+				envVal := getenv(key)
+				if len(envVal) == 0 {
+					return defaultVal
+				}
+				return []byte(envVal)
 			}
-			return []byte(envVal)
-		}
 
-		// {osenv.变量名} 无默认值的部分
-		return []byte(os.Getenv(string(keyWithDefaultVal)))
-	})
-	return contentNew, nil
+			// {osenv.变量名} 无默认值的部分
+			return []byte(getenv(string(keyWithDefaultVal)))
+		})
+		return contentNew, nil
+	}
 }
@@ -11,16 +11,25 @@ import (
 // StripComment 去除单行的'#'注释
 // 只支持单行，不支持行尾
 func StripComment(input []byte) (out []byte) {
+	return bytes.TrimSpace(BlankComment(input))
+}
+
+// BlankComment 将单行的'#'注释替换为空行，
+// 和 StripComment 不同，会保留所有的行，以便于定位错误所在的行号
+func BlankComment(input []byte) []byte {
 	var buf bytes.Buffer
+	buf.Grow(len(input))
 	lines := bytes.Split(input, []byte("\n"))
-	for _, line := range lines {
+	for i, line := range lines {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
 		lineN := bytes.TrimSpace(line)
 		if !bytes.HasPrefix(lineN, []byte("#")) {
 			buf.Write(line)
 		}
-		buf.WriteString("\n")
 	}
-	return bytes.TrimSpace(buf.Bytes())
+	return buf.Bytes()
 }
 
 // HeadComments 获取头部的所有注释内容
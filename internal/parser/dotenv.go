@@ -0,0 +1,145 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/fsgo/fsconf/internal/tree"
+)
+
+// DotEnvTag 解析 .env 文件到 struct 时，用于查找字段名称的 tag，若没有，则使用字段名（不区分大小写）
+const DotEnvTag = "env"
+
+// DotEnv .env 文件的解析方法，可以解析到 map[string]string 或者 struct 上，
+// 所有的值都是字符串，解析到 slice 类型的字段时，会作为只有一个元素的数组
+//
+//	# 注释
+//	NAME=demo
+//	export PORT=8080          # 行尾的注释
+//	DESC="支持转义\n，可以
+//	跨越多行"
+//	RAW='原样输出 \n'
+func DotEnv(txt []byte, obj any) error {
+	vars, err := ParseDotEnv(txt)
+	if err != nil {
+		return err
+	}
+	data := make(map[string]any, len(vars))
+	for k, v := range vars {
+		data[k] = v
+	}
+	return tree.DecodeWithOptions(data, obj, tree.Options{Tags: []string{DotEnvTag}, SingleAsList: true})
+}
+
+var dotEnvKeyReg = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+
+// ParseDotEnv 解析 .env 格式的内容，重复的 key，后面的会覆盖前面的
+func ParseDotEnv(txt []byte) (map[string]string, error) {
+	result := map[string]string{}
+	str := strings.ReplaceAll(string(txt), "\r\n", "\n")
+	lineNo := 0
+	for len(str) > 0 {
+		var line string
+		line, str, _ = strings.Cut(str, "\n")
+		lineNo++
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || !dotEnvKeyReg.MatchString(key) {
+			return nil, &PositionError{Line: lineNo, Err: fmt.Errorf("invalid line %q", line)}
+		}
+		value = strings.TrimLeft(value, " \t")
+		if value != "" && (value[0] == '"' || value[0] == '\'') {
+			// 带引号的值可以跨越多行
+			start := lineNo
+			for !dotEnvQuoted(value) && len(str) > 0 {
+				var next string
+				next, str, _ = strings.Cut(str, "\n")
+				lineNo++
+				value += "\n" + next
+			}
+			v, err := dotEnvQuotedValue(value)
+			if err != nil {
+				return nil, &PositionError{Line: start, Err: fmt.Errorf("%s: %w", key, err)}
+			}
+			result[key] = v
+			continue
+		}
+		// 行尾的注释，需要以空白字符开头
+		if idx := strings.Index(value, " #"); idx >= 0 {
+			value = value[:idx]
+		}
+		if idx := strings.Index(value, "\t#"); idx >= 0 {
+			value = value[:idx]
+		}
+		result[key] = strings.TrimSpace(value)
+	}
+	return result, nil
+}
+
+// dotEnvQuoted 判断引号是否已经闭合
+func dotEnvQuoted(value string) bool {
+	return dotEnvQuoteEnd(value) > 0
+}
+
+// dotEnvQuoteEnd 返回结束的引号所在的位置，找不到时返回 -1
+func dotEnvQuoteEnd(value string) int {
+	q := value[0]
+	for i := 1; i < len(value); i++ {
+		if q == '"' && value[i] == '\\' {
+			i++
+			continue
+		}
+		if value[i] == q {
+			return i
+		}
+	}
+	return -1
+}
+
+func dotEnvQuotedValue(value string) (string, error) {
+	end := dotEnvQuoteEnd(value)
+	if end < 0 {
+		return "", errors.New("unterminated quoted value")
+	}
+	rest := strings.TrimSpace(value[end+1:])
+	if rest != "" && rest[0] != '#' {
+		return "", fmt.Errorf("unexpected %q after quoted value", rest)
+	}
+	body := value[1:end]
+	if value[0] == '\'' {
+		return body, nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(body); i++ {
+		if body[i] != '\\' || i == len(body)-1 {
+			b.WriteByte(body[i])
+			continue
+		}
+		i++
+		switch body[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case '"', '\\', '$', '\'':
+			b.WriteByte(body[i])
+		default:
+			b.WriteByte('\\')
+			b.WriteByte(body[i])
+		}
+	}
+	return b.String(), nil
+}
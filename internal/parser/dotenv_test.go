@@ -0,0 +1,79 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package parser
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fsgo/fst"
+)
+
+func TestParseDotEnv(t *testing.T) {
+	txt := `# comment
+NAME=demo
+export PORT = 8080 # port
+EMPTY=
+URL=http://a.com/#frag
+DESC="a\n\"b\" # c"
+RAW='x\ny'
+MULTI="line1
+line2"
+NAME=demo2
+`
+	got, err := ParseDotEnv([]byte(txt))
+	fst.NoError(t, err)
+	want := map[string]string{
+		"NAME":  "demo2",
+		"PORT":  "8080",
+		"EMPTY": "",
+		"URL":   "http://a.com/#frag",
+		"DESC":  "a\n\"b\" # c",
+		"RAW":   `x\ny`,
+		"MULTI": "line1\nline2",
+	}
+	fst.Equal(t, want, got)
+}
+
+func TestParseDotEnv_error(t *testing.T) {
+	tests := []struct {
+		txt  string
+		line int
+	}{
+		{txt: "A=1\nB", line: 2},
+		{txt: "1A=1", line: 1},
+		{txt: "A=1\nB=\"abc\nC=1", line: 2},
+		{txt: "A='x' y", line: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.txt, func(t *testing.T) {
+			_, err := ParseDotEnv([]byte(tt.txt))
+			var pe *PositionError
+			fst.True(t, errors.As(err, &pe))
+			fst.Equal(t, tt.line, pe.Line)
+		})
+	}
+}
+
+func TestDotEnv(t *testing.T) {
+	type config struct {
+		Name string `env:"APP_NAME"`
+		Port int    `env:"APP_PORT"`
+		Tags []string
+	}
+	var cfg config
+	fst.NoError(t, DotEnv([]byte("APP_NAME=demo\nAPP_PORT=80\n"), &cfg))
+	fst.Equal(t, config{Name: "demo", Port: 80}, cfg)
+
+	var m map[string]string
+	fst.NoError(t, DotEnv([]byte("A=1\n"), &m))
+	fst.Equal(t, map[string]string{"A": "1"}, m)
+
+	t.Run("single value into slice", func(t *testing.T) {
+		var cfg config
+		fst.NoError(t, DotEnv([]byte("TAGS=a,b\n"), &cfg))
+		fst.Equal(t, []string{"a,b"}, cfg.Tags)
+	})
+}
@@ -0,0 +1,203 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fsgo/fsconf/internal/tree"
+)
+
+// EncodeJSON 将 obj 编码为带缩进的 JSON
+func EncodeJSON(obj any) ([]byte, error) {
+	bf, err := json.MarshalIndent(obj, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(bf, '\n'), nil
+}
+
+// EncodeINI 将 obj 编码为 .ini 格式，嵌套的 map 或者 struct 会编码为 section，数组使用 key[] 的格式
+func EncodeINI(obj any) ([]byte, error) {
+	data, err := encodeMap(obj, INITag)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err = writeINISection(&buf, "", data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeINISection(buf *bytes.Buffer, name string, data map[string]any) error {
+	keys := tree.SortedKeys(data)
+	var sections []string
+	for _, k := range keys {
+		switch v := data[k].(type) {
+		case map[string]any:
+			sections = append(sections, k)
+		case []any:
+			for _, item := range v {
+				str, err := scalarString(joinDot(name, k), item)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(buf, "%s[] = %s\n", k, quoteINI(str))
+			}
+		default:
+			str, err := scalarString(joinDot(name, k), v)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(buf, "%s = %s\n", k, quoteINI(str))
+		}
+	}
+	for _, k := range sections {
+		sub := joinDot(name, k)
+		if buf.Len() > 0 {
+			buf.WriteString("\n")
+		}
+		fmt.Fprintf(buf, "[%s]\n", sub)
+		if err := writeINISection(buf, sub, data[k].(map[string]any)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// quoteINI 当值中包含注释符号、引号或者首尾有空白字符时，使用双引号
+func quoteINI(str string) string {
+	if str == "" {
+		return str
+	}
+	if strings.ContainsAny(str, ";#\"'\n\r\\") || strings.TrimSpace(str) != str {
+		return strconv.Quote(str)
+	}
+	return str
+}
+
+// EncodeProperties 将 obj 编码为 .properties 格式，嵌套的 map 或者 struct 使用 "." 连接 key，不支持数组
+func EncodeProperties(obj any) ([]byte, error) {
+	data, err := encodeMap(obj)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err = writeProperties(&buf, "", data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeProperties(buf *bytes.Buffer, prefix string, data map[string]any) error {
+	for _, k := range tree.SortedKeys(data) {
+		key := joinDot(prefix, k)
+		if m, ok := data[k].(map[string]any); ok {
+			if err := writeProperties(buf, key, m); err != nil {
+				return err
+			}
+			continue
+		}
+		str, err := scalarString(key, data[k])
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(buf, "%s=%s\n", escapeProperty(key, true), escapeProperty(str, false))
+	}
+	return nil
+}
+
+func escapeProperty(str string, isKey bool) string {
+	var b strings.Builder
+	for i, r := range str {
+		switch r {
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '=', ':', '#', '!':
+			if isKey || i == 0 {
+				b.WriteByte('\\')
+			}
+			b.WriteRune(r)
+		case ' ':
+			if isKey || i == 0 {
+				b.WriteByte('\\')
+			}
+			b.WriteRune(r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// EncodeDotEnv 将 obj 编码为 .env 格式，只支持一层的 map 或者 struct
+func EncodeDotEnv(obj any) ([]byte, error) {
+	data, err := encodeMap(obj, DotEnvTag)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	for _, k := range tree.SortedKeys(data) {
+		if !dotEnvKeyReg.MatchString(k) {
+			return nil, fmt.Errorf("invalid env name %q", k)
+		}
+		str, err := scalarString(k, data[k])
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&buf, "%s=%s\n", k, quoteDotEnv(str))
+	}
+	return buf.Bytes(), nil
+}
+
+func quoteDotEnv(str string) string {
+	if !strings.ContainsAny(str, " \t\n\r#\"'\\$") {
+		return str
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`, "$", `\$`)
+	return `"` + r.Replace(str) + `"`
+}
+
+func encodeMap(obj any, tags ...string) (map[string]any, error) {
+	data, err := tree.Encode(obj, tags...)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := data.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("cannot encode %T, must be a map or struct", obj)
+	}
+	return m, nil
+}
+
+func scalarString(key string, v any) (string, error) {
+	switch v.(type) {
+	case map[string]any, []any:
+		return "", fmt.Errorf("%q: nested value is not supported", key)
+	case nil:
+		return "", nil
+	}
+	return fmt.Sprint(v), nil
+}
+
+func joinDot(prefix string, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
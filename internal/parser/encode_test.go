@@ -0,0 +1,74 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package parser
+
+import (
+	"testing"
+
+	"github.com/fsgo/fst"
+)
+
+func TestEncodeINI(t *testing.T) {
+	data := map[string]any{
+		"name": " demo ",
+		"tags": []string{"a", "b"},
+		"db": map[string]any{
+			"host":    "127.0.0.1",
+			"comment": "a;b",
+			"replica": map[string]any{"port": 3307},
+		},
+	}
+	bf, err := EncodeINI(data)
+	fst.NoError(t, err)
+	want := `name = " demo "
+tags[] = a
+tags[] = b
+
+[db]
+comment = "a;b"
+host = 127.0.0.1
+
+[db.replica]
+port = 3307
+`
+	fst.Equal(t, want, string(bf))
+
+	_, err = EncodeINI("abc")
+	fst.Error(t, err)
+	_, err = EncodeINI(map[string]any{"a": []any{map[string]any{"b": 1}}})
+	fst.Error(t, err)
+}
+
+func TestEncodeProperties(t *testing.T) {
+	data := map[string]any{
+		"key with space": "a=b",
+		"db":             map[string]any{"host": " 127.0.0.1", "path": `c:\dir`},
+	}
+	bf, err := EncodeProperties(data)
+	fst.NoError(t, err)
+	want := `db.host=\ 127.0.0.1
+db.path=c:\\dir
+key\ with\ space=a=b
+`
+	fst.Equal(t, want, string(bf))
+
+	got, err := parseProperties(bf)
+	fst.NoError(t, err)
+	fst.Equal(t, data, got)
+
+	_, err = EncodeProperties(map[string]any{"a": []int{1}})
+	fst.Error(t, err)
+}
+
+func TestEncodeDotEnv(t *testing.T) {
+	bf, err := EncodeDotEnv(map[string]any{"A": "1", "B": "x $y", "C": 2})
+	fst.NoError(t, err)
+	fst.Equal(t, "A=1\nB=\"x \\$y\"\nC=2\n", string(bf))
+
+	_, err = EncodeDotEnv(map[string]any{"a-b": "1"})
+	fst.Error(t, err)
+	_, err = EncodeDotEnv(map[string]any{"A": map[string]any{"B": 1}})
+	fst.Error(t, err)
+}
@@ -0,0 +1,203 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package parser
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fsgo/fsconf/internal/tree"
+)
+
+// INITag 解析 .ini 文件到 struct 时，用于查找字段名称的 tag，若没有，则使用字段名（不区分大小写）
+const INITag = "ini"
+
+// INI .ini 文件的解析方法
+//
+//	; 注释，也可以使用 #
+//	name = demo
+//	[db]              ; section 对应 struct 或者 map，db.primary 表示嵌套的 section
+//	host = "127.0.0.1"
+//	hosts = a         ; 重复的 key 会解析为数组，解析到 slice 时，只出现一次的 key 会作为只有一个元素的数组
+//	hosts = b
+//	ports[] = 80      ; 以 [] 结尾的 key 总是解析为数组
+//
+// 所有的值都是字符串，解析到 struct 时，会转换为字段的类型
+func INI(txt []byte, obj any) error {
+	data, err := parseINI(txt)
+	if err != nil {
+		return err
+	}
+	return tree.DecodeWithOptions(data, obj, tree.Options{Tags: []string{INITag}, SingleAsList: true})
+}
+
+func parseINI(txt []byte) (map[string]any, error) {
+	root := map[string]any{}
+	section := root
+	var sectionPath []string
+	for i, line := range bytes.Split(txt, []byte("\n")) {
+		lineNo := i + 1
+		str := strings.TrimSpace(string(line))
+		if str == "" || str[0] == ';' || str[0] == '#' {
+			continue
+		}
+		if str[0] == '[' {
+			name, ok := iniSectionName(str)
+			if !ok {
+				return nil, &PositionError{Line: lineNo, Err: fmt.Errorf("invalid section %q", str)}
+			}
+			sectionPath = strings.Split(name, ".")
+			m, err := mapByPath(root, sectionPath)
+			if err != nil {
+				return nil, &PositionError{Line: lineNo, Err: err}
+			}
+			section = m
+			continue
+		}
+		idx := strings.IndexAny(str, "=:")
+		if idx <= 0 {
+			return nil, &PositionError{Line: lineNo, Err: errors.New(`missing "=" after key`)}
+		}
+		key := strings.TrimSpace(str[:idx])
+		value, err := iniValue(strings.TrimSpace(str[idx+1:]))
+		if err != nil {
+			return nil, &PositionError{Line: lineNo, Column: strings.Index(string(line), str[idx+1:]) + 1, Err: err}
+		}
+		if err = setValue(section, key, value); err != nil {
+			return nil, &PositionError{Line: lineNo, Err: fmt.Errorf("%s: %w", strings.Join(append(sectionPath, key), "."), err)}
+		}
+	}
+	return root, nil
+}
+
+// iniSectionName 读取 [name] 中的 name，允许在其后有注释
+func iniSectionName(str string) (string, bool) {
+	end := strings.IndexByte(str, ']')
+	if end < 0 {
+		return "", false
+	}
+	rest := strings.TrimSpace(str[end+1:])
+	if rest != "" && rest[0] != ';' && rest[0] != '#' {
+		return "", false
+	}
+	name := strings.TrimSpace(str[1:end])
+	if name == "" {
+		return "", false
+	}
+	for _, s := range strings.Split(name, ".") {
+		if strings.TrimSpace(s) == "" {
+			return "", false
+		}
+	}
+	return name, true
+}
+
+// iniValue 解析值，支持双引号（可使用转义字符）、单引号（原样输出）以及行尾的注释
+func iniValue(str string) (string, error) {
+	if str == "" {
+		return "", nil
+	}
+	switch str[0] {
+	case '"':
+		end := closingQuote(str)
+		if end < 0 {
+			return "", errors.New("unterminated quoted value")
+		}
+		if err := checkTrailing(str[end+1:]); err != nil {
+			return "", err
+		}
+		return strconv.Unquote(str[:end+1])
+	case '\'':
+		end := strings.IndexByte(str[1:], '\'')
+		if end < 0 {
+			return "", errors.New("unterminated quoted value")
+		}
+		if err := checkTrailing(str[end+2:]); err != nil {
+			return "", err
+		}
+		return str[1 : end+1], nil
+	}
+	// 行尾的注释，需要以空白字符开头，如 "a ; comment"
+	for i := 1; i < len(str); i++ {
+		if (str[i] == ';' || str[i] == '#') && (str[i-1] == ' ' || str[i-1] == '\t') {
+			return strings.TrimSpace(str[:i]), nil
+		}
+	}
+	return str, nil
+}
+
+// closingQuote 返回双引号字符串结束的位置，找不到时返回 -1
+func closingQuote(str string) int {
+	for i := 1; i < len(str); i++ {
+		switch str[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+func checkTrailing(str string) error {
+	str = strings.TrimSpace(str)
+	if str == "" || str[0] == ';' || str[0] == '#' {
+		return nil
+	}
+	return fmt.Errorf("unexpected %q after quoted value", str)
+}
+
+// mapByPath 返回 root 中 path 对应的 map，不存在时会创建
+func mapByPath(root map[string]any, path []string) (map[string]any, error) {
+	cur := root
+	for i, name := range path {
+		name = strings.TrimSpace(name)
+		v, ok := cur[name]
+		if !ok {
+			m := map[string]any{}
+			cur[name] = m
+			cur = m
+			continue
+		}
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%q is already defined as a value", strings.Join(path[:i+1], "."))
+		}
+		cur = m
+	}
+	return cur, nil
+}
+
+// setValue 设置 m[key] 的值，重复的 key 或者以 [] 结尾的 key 会解析为数组
+func setValue(m map[string]any, key string, value string) error {
+	if name, ok := strings.CutSuffix(key, "[]"); ok {
+		key = strings.TrimSpace(name)
+		switch old := m[key].(type) {
+		case nil:
+			m[key] = []any{value}
+		case []any:
+			m[key] = append(old, value)
+		case string:
+			m[key] = []any{old, value}
+		default:
+			return fmt.Errorf("%q is already defined as a section", key)
+		}
+		return nil
+	}
+	switch old := m[key].(type) {
+	case nil:
+		m[key] = value
+	case string:
+		m[key] = []any{old, value}
+	case []any:
+		m[key] = append(old, value)
+	default:
+		return fmt.Errorf("%q is already defined as a section", key)
+	}
+	return nil
+}
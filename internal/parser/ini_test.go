@@ -0,0 +1,110 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package parser
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fsgo/fst"
+)
+
+func TestINI(t *testing.T) {
+	txt := `
+; comment
+# comment
+name = demo ; inline comment
+desc = "a ; b \"c\"\n"
+raw = 'x # y'
+timeout = 3s
+
+[db]
+host: 127.0.0.1
+port = 3306
+hosts = a
+hosts = b
+tags[] = t1
+
+[db.primary]
+weight = 10
+
+[Extra]
+Enable = true
+`
+	type primary struct {
+		Weight int `ini:"weight"`
+	}
+	type db struct {
+		Host    string   `ini:"host"`
+		Port    int      `ini:"port"`
+		Hosts   []string `ini:"hosts"`
+		Tags    []string `ini:"tags"`
+		Primary primary  `ini:"primary"`
+	}
+	type config struct {
+		Name    string `ini:"name" json:"other"`
+		Desc    string
+		Raw     string
+		Timeout time.Duration
+		DB      db `ini:"db"`
+		Extra   map[string]bool
+	}
+	var got config
+	fst.NoError(t, INI([]byte(txt), &got))
+	want := config{
+		Name:    "demo",
+		Desc:    "a ; b \"c\"\n",
+		Raw:     "x # y",
+		Timeout: 3 * time.Second,
+		DB: db{
+			Host:    "127.0.0.1",
+			Port:    3306,
+			Hosts:   []string{"a", "b"},
+			Tags:    []string{"t1"},
+			Primary: primary{Weight: 10},
+		},
+		Extra: map[string]bool{"Enable": true},
+	}
+	fst.Equal(t, want, got)
+
+	var m map[string]any
+	fst.NoError(t, INI([]byte(txt), &m))
+	fst.Equal[any](t, "3306", m["db"].(map[string]any)["port"])
+
+	t.Run("single value into slice", func(t *testing.T) {
+		var cfg struct {
+			Hosts []string `ini:"hosts"`
+			Ports [2]int   `ini:"ports"`
+		}
+		fst.NoError(t, INI([]byte("hosts = a\nports = 80"), &cfg))
+		fst.Equal(t, []string{"a"}, cfg.Hosts)
+		fst.Equal(t, [2]int{80, 0}, cfg.Ports)
+	})
+}
+
+func TestINI_error(t *testing.T) {
+	tests := []struct {
+		txt  string
+		line int
+	}{
+		{txt: "a = 1\n[a]", line: 2},
+		{txt: "[a]\nb", line: 2},
+		{txt: "[a", line: 1},
+		{txt: "[a.]", line: 1},
+		{txt: "\na = \"b", line: 2},
+		{txt: "a = 'b' c", line: 1},
+		{txt: "[a.b]\n[a]\nb=1", line: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.txt, func(t *testing.T) {
+			var m map[string]any
+			err := INI([]byte(tt.txt), &m)
+			var pe *PositionError
+			fst.True(t, errors.As(err, &pe))
+			fst.Equal(t, tt.line, pe.Line)
+		})
+	}
+}
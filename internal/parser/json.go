@@ -7,13 +7,53 @@ package parser
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 )
 
 // JSON .json 文件的解析方法
-// 若内容以 # 开头，则该为注释
+// 支持 #、// 和 /* */ 注释（字符串中的不受影响），以及数组和对象最后多余的逗号
 func JSON(txt []byte, obj any) error {
-	bf := StripComment(txt)
+	return decodeJSON(txt, obj, false)
+}
+
+// JSON5 .json5 文件的解析方法
+// 在 JSON 的基础上，还支持不带引号的 key 和单引号的字符串
+func JSON5(txt []byte, obj any) error {
+	return decodeJSON(txt, obj, true)
+}
+
+func decodeJSON(txt []byte, obj any, json5 bool) error {
+	bf, err := relaxJSON(txt, json5)
+	if err != nil {
+		return err
+	}
 	dec := json.NewDecoder(bytes.NewReader(bf))
 	dec.UseNumber()
-	return dec.Decode(obj)
+	err = dec.Decode(obj)
+	if err == nil {
+		return nil
+	}
+	return jsonPositionError(bf, err)
+}
+
+// jsonPositionError 将 encoding/json 返回的 offset 转换为行号和列号
+func jsonPositionError(bf []byte, err error) error {
+	var offset int64
+	var se *json.SyntaxError
+	var ue *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &se):
+		// Offset 是已经读取的字节数，出错的字符是最后读取的一个
+		offset = se.Offset - 1
+	case errors.As(err, &ue):
+		offset = ue.Offset - 1
+	default:
+		return err
+	}
+	line, column := OffsetPosition(bf, int(offset))
+	return &PositionError{
+		Line:   line,
+		Column: column,
+		Err:    err,
+	}
 }
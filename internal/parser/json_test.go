@@ -5,6 +5,7 @@
 package parser
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -51,3 +52,16 @@ func Test_JSONParser(t *testing.T) {
 		})
 	}
 }
+
+func TestJSON_position(t *testing.T) {
+	txt := "# comment\n{\n  \"a\": 1,\n  \"b\": ,\n}"
+	var obj map[string]any
+	err := JSON([]byte(txt), &obj)
+	var pe *PositionError
+	if !errors.As(err, &pe) {
+		t.Fatalf("want PositionError, got %v", err)
+	}
+	if pe.Line != 4 || pe.Column != 8 {
+		t.Errorf("got line=%d column=%d, want line=4 column=8", pe.Line, pe.Column)
+	}
+}
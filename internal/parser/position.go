@@ -0,0 +1,42 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package parser
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// PositionError 包含出错位置的解析错误
+type PositionError struct {
+	Err    error
+	Line   int // 从 1 开始
+	Column int // 从 1 开始，为 0 时表示未知
+}
+
+func (e *PositionError) Error() string {
+	if e.Column > 0 {
+		return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Err.Error())
+	}
+	return fmt.Sprintf("line %d: %s", e.Line, e.Err.Error())
+}
+
+func (e *PositionError) Unwrap() error {
+	return e.Err
+}
+
+// OffsetPosition 返回 offset 在 content 中所在的行和列，都从 1 开始
+func OffsetPosition(content []byte, offset int) (line int, column int) {
+	if offset > len(content) {
+		offset = len(content)
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	before := content[:offset]
+	line = bytes.Count(before, []byte("\n")) + 1
+	column = offset - bytes.LastIndexByte(before, '\n')
+	return line, column
+}
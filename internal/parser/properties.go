@@ -0,0 +1,167 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+
+	"github.com/fsgo/fsconf/internal/tree"
+)
+
+// Properties Java .properties 文件的解析方法
+//
+//	# 注释，也可以使用 !
+//	db.primary.host = 127.0.0.1
+//	db.primary.port: 3306
+//	name = 中文
+//	desc = line1 \
+//	       line2
+//
+// 使用 "." 分隔的 key 会解析为嵌套的结构，和 json、toml 一样使用 tree.Tags 查找字段的名称。
+// 所有的值都是字符串，解析到 struct 时，会转换为字段的类型，
+// 解析到 slice 类型的字段时，值会作为只有一个元素的数组。
+func Properties(txt []byte, obj any) error {
+	data, err := parseProperties(txt)
+	if err != nil {
+		return err
+	}
+	return tree.DecodeWithOptions(data, obj, tree.Options{SingleAsList: true})
+}
+
+func parseProperties(txt []byte) (map[string]any, error) {
+	root := map[string]any{}
+	lines := strings.Split(strings.ReplaceAll(string(txt), "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		lineNo := i + 1
+		line := strings.TrimLeft(lines[i], " \t\f")
+		if line == "" || line[0] == '#' || line[0] == '!' {
+			continue
+		}
+		// 以奇数个 \ 结尾的行，和下一行连接，下一行开头的空白字符会被忽略
+		for continued(line) && i+1 < len(lines) {
+			i++
+			line = line[:len(line)-1] + strings.TrimLeft(lines[i], " \t\f")
+		}
+		rawKey, rawValue := splitProperty(line)
+		key, err := unescapeProperty(rawKey)
+		if err != nil {
+			return nil, &PositionError{Line: lineNo, Err: err}
+		}
+		value, err := unescapeProperty(rawValue)
+		if err != nil {
+			return nil, &PositionError{Line: lineNo, Err: fmt.Errorf("%s: %w", key, err)}
+		}
+		if err = setPropertyValue(root, key, value); err != nil {
+			return nil, &PositionError{Line: lineNo, Err: err}
+		}
+	}
+	return root, nil
+}
+
+// continued 是否以奇数个 \ 结尾
+func continued(line string) bool {
+	n := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		n++
+	}
+	return n%2 == 1
+}
+
+// splitProperty 分割 key 和 value，分隔符为第一个未转义的 =、: 或者空白字符
+func splitProperty(line string) (key string, value string) {
+	end := len(line)
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		if c == '\\' {
+			i++
+			continue
+		}
+		if c == '=' || c == ':' || c == ' ' || c == '\t' || c == '\f' {
+			end = i
+			break
+		}
+	}
+	key = line[:end]
+	rest := strings.TrimLeft(line[end:], " \t\f")
+	// 空白字符之后，可以再有一个 = 或者 :
+	if rest != "" && (rest[0] == '=' || rest[0] == ':') {
+		rest = strings.TrimLeft(rest[1:], " \t\f")
+	}
+	return key, rest
+}
+
+// unescapeProperty 处理转义字符，支持 \t、\n、\r、\f 以及 \uXXXX，其他的 \x 表示 x 本身
+func unescapeProperty(str string) (string, error) {
+	if !strings.Contains(str, `\`) {
+		return str, nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(str); i++ {
+		c := str[i]
+		if c != '\\' || i == len(str)-1 {
+			b.WriteByte(c)
+			continue
+		}
+		i++
+		switch str[i] {
+		case 't':
+			b.WriteByte('\t')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 'f':
+			b.WriteByte('\f')
+		case 'u':
+			if i+5 > len(str) {
+				return "", errors.New(`invalid \u escape`)
+			}
+			n, err := strconv.ParseUint(str[i+1:i+5], 16, 16)
+			if err != nil {
+				return "", fmt.Errorf(`invalid \u escape %q`, str[i-1:i+5])
+			}
+			r := rune(n)
+			i += 4
+			// 非 BMP 的字符（如 emoji）使用 UTF-16 代理对表示，如 \uD83D\uDE00
+			if utf16.IsSurrogate(r) && i+6 < len(str) && str[i+1] == '\\' && str[i+2] == 'u' {
+				if n2, err := strconv.ParseUint(str[i+3:i+7], 16, 16); err == nil {
+					if r2 := utf16.DecodeRune(r, rune(n2)); r2 != unicode.ReplacementChar {
+						r = r2
+						i += 6
+					}
+				}
+			}
+			b.WriteRune(r)
+		default:
+			b.WriteByte(str[i])
+		}
+	}
+	return b.String(), nil
+}
+
+// setPropertyValue 按照 "." 分隔的 key 设置嵌套的值，同名的 key 后面的覆盖前面的
+func setPropertyValue(root map[string]any, key string, value string) error {
+	path := strings.Split(key, ".")
+	for _, s := range path {
+		if s == "" {
+			return fmt.Errorf("invalid key %q", key)
+		}
+	}
+	m, err := mapByPath(root, path[:len(path)-1])
+	if err != nil {
+		return err
+	}
+	name := path[len(path)-1]
+	if _, ok := m[name].(map[string]any); ok {
+		return fmt.Errorf("%q is already defined as a parent key", key)
+	}
+	m[name] = value
+	return nil
+}
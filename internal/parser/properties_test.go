@@ -0,0 +1,95 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package parser
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fsgo/fst"
+)
+
+func TestProperties(t *testing.T) {
+	txt := `# comment
+! comment
+name = demo
+db.primary.host = 127.0.0.1
+db.primary.port: 3306
+db.replica.host   10.0.0.1
+desc = line1 \
+       line2
+cn = \u4e2d\u6587
+key\ with\ space = a\=b
+path = c:\\dir\\
+empty
+name = demo2
+`
+	type node struct {
+		Host string `toml:"host"`
+		Port int    `toml:"port"`
+	}
+	type config struct {
+		Name string `toml:"name"`
+		DB   struct {
+			Primary node           `toml:"primary"`
+			Others  map[string]any `toml:"others"`
+		} `toml:"db"`
+		Desc string
+		CN   string
+	}
+	var cfg config
+	fst.NoError(t, Properties([]byte(txt), &cfg))
+	fst.Equal(t, "demo2", cfg.Name)
+	fst.Equal(t, node{Host: "127.0.0.1", Port: 3306}, cfg.DB.Primary)
+	fst.Equal(t, "line1 line2", cfg.Desc)
+	fst.Equal(t, "中文", cfg.CN)
+
+	var m map[string]any
+	fst.NoError(t, Properties([]byte(txt), &m))
+	fst.Equal[any](t, "10.0.0.1", m["db"].(map[string]any)["replica"].(map[string]any)["host"])
+	fst.Equal[any](t, "a=b", m["key with space"])
+	fst.Equal[any](t, `c:\dir\`, m["path"])
+	fst.Equal[any](t, "", m["empty"])
+
+	t.Run("surrogate pair", func(t *testing.T) {
+		var m map[string]any
+		fst.NoError(t, Properties([]byte(`a = \uD83D\uDE00!
+b = \ud83d
+c = \uD83Dx`), &m))
+		fst.Equal[any](t, "😀!", m["a"])
+		fst.Equal[any](t, "\uFFFD", m["b"])
+		fst.Equal[any](t, "\uFFFDx", m["c"])
+	})
+
+	t.Run("single value into slice", func(t *testing.T) {
+		var cfg struct {
+			Hosts []string `toml:"hosts"`
+		}
+		fst.NoError(t, Properties([]byte("hosts = a"), &cfg))
+		fst.Equal(t, []string{"a"}, cfg.Hosts)
+	})
+}
+
+func TestProperties_error(t *testing.T) {
+	tests := []struct {
+		txt  string
+		line int
+	}{
+		{txt: "a=1\na.b=2", line: 2},
+		{txt: "a.b=1\na=2", line: 2},
+		{txt: "a..b=1", line: 1},
+		{txt: "\na=\\u12", line: 2},
+		{txt: "a=\\u12zz", line: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.txt, func(t *testing.T) {
+			var m map[string]any
+			err := Properties([]byte(tt.txt), &m)
+			var pe *PositionError
+			fst.True(t, errors.As(err, &pe))
+			fst.Equal(t, tt.line, pe.Line)
+		})
+	}
+}
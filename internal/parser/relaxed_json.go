@@ -0,0 +1,227 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package parser
+
+import (
+	"errors"
+)
+
+// relaxJSON 将宽松格式的 JSON 转换为标准的 JSON，会保留所有的换行，以便于定位错误所在的行号
+//
+// 支持 // 、/* */ 和 # 注释，以及数组和对象最后多余的逗号；
+// 当 json5=true 时，还支持不带引号的 key 和单引号的字符串
+func relaxJSON(src []byte, json5 bool) ([]byte, error) {
+	r := &jsonRelaxer{src: src, json5: json5}
+	if err := r.run(); err != nil {
+		return nil, err
+	}
+	return r.out, nil
+}
+
+type jsonRelaxer struct {
+	src   []byte
+	out   []byte
+	stack []byte // 当前所在的容器，'{' 或者 '['
+	last  byte   // 上一个有效的字符
+	json5 bool
+}
+
+func (r *jsonRelaxer) run() error {
+	r.out = make([]byte, 0, len(r.src))
+	for i := 0; i < len(r.src); {
+		c := r.src[i]
+		switch {
+		case c == '"':
+			end, err := r.stringEnd(i, '"')
+			if err != nil {
+				return err
+			}
+			r.out = append(r.out, r.src[i:end]...)
+			r.last = '"'
+			i = end
+		case c == '\'' && r.json5:
+			end, err := r.stringEnd(i, '\'')
+			if err != nil {
+				return err
+			}
+			r.writeSingleQuoted(r.src[i+1 : end-1])
+			r.last = '"'
+			i = end
+		case r.isCommentStart(i):
+			end, err := r.commentEnd(i)
+			if err != nil {
+				return err
+			}
+			r.blank(r.src[i:end])
+			i = end
+		case c == ',':
+			if n := r.nextSignificant(i + 1); r.afterValue() && n < len(r.src) && (r.src[n] == '}' || r.src[n] == ']') {
+				// 多余的逗号
+				r.out = append(r.out, ' ')
+			} else {
+				r.out = append(r.out, c)
+				r.last = c
+			}
+			i++
+		case c == '{' || c == '[':
+			r.stack = append(r.stack, c)
+			r.out = append(r.out, c)
+			r.last = c
+			i++
+		case c == '}' || c == ']':
+			if len(r.stack) > 0 {
+				r.stack = r.stack[:len(r.stack)-1]
+			}
+			r.out = append(r.out, c)
+			r.last = c
+			i++
+		case r.json5 && isIdentStart(c) && r.expectKey():
+			end := i + 1
+			for end < len(r.src) && isIdentPart(r.src[end]) {
+				end++
+			}
+			r.out = append(r.out, '"')
+			r.out = append(r.out, r.src[i:end]...)
+			r.out = append(r.out, '"')
+			r.last = '"'
+			i = end
+		default:
+			r.out = append(r.out, c)
+			if !isJSONSpace(c) {
+				r.last = c
+			}
+			i++
+		}
+	}
+	return nil
+}
+
+// afterValue 上一个有效的字符是否是一个值的结尾，如 "a": , 中的逗号不是多余的逗号
+func (r *jsonRelaxer) afterValue() bool {
+	switch r.last {
+	case 0, ':', ',', '{', '[':
+		return false
+	}
+	return true
+}
+
+// expectKey 当前位置是否应该是对象的 key
+func (r *jsonRelaxer) expectKey() bool {
+	if len(r.stack) == 0 || r.stack[len(r.stack)-1] != '{' {
+		return false
+	}
+	return r.last == '{' || r.last == ','
+}
+
+// stringEnd 返回字符串结束之后的位置
+func (r *jsonRelaxer) stringEnd(start int, quote byte) (int, error) {
+	for i := start + 1; i < len(r.src); i++ {
+		switch r.src[i] {
+		case '\\':
+			i++
+		case '\n':
+			return 0, r.errorAt(start, errors.New("unterminated string"))
+		case quote:
+			return i + 1, nil
+		}
+	}
+	return 0, r.errorAt(start, errors.New("unterminated string"))
+}
+
+// writeSingleQuoted 将单引号字符串的内容转换为双引号字符串
+func (r *jsonRelaxer) writeSingleQuoted(body []byte) {
+	r.out = append(r.out, '"')
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		switch {
+		case c == '\\' && i+1 < len(body) && body[i+1] == '\'':
+			r.out = append(r.out, '\'')
+			i++
+		case c == '\\' && i+1 < len(body):
+			r.out = append(r.out, c, body[i+1])
+			i++
+		case c == '"':
+			r.out = append(r.out, '\\', '"')
+		default:
+			r.out = append(r.out, c)
+		}
+	}
+	r.out = append(r.out, '"')
+}
+
+func (r *jsonRelaxer) isCommentStart(i int) bool {
+	c := r.src[i]
+	if c == '#' {
+		return true
+	}
+	return c == '/' && i+1 < len(r.src) && (r.src[i+1] == '/' || r.src[i+1] == '*')
+}
+
+// commentEnd 返回注释结束之后的位置，行注释不包含最后的换行
+func (r *jsonRelaxer) commentEnd(start int) (int, error) {
+	if r.src[start] == '/' && r.src[start+1] == '*' {
+		for i := start + 2; i+1 < len(r.src); i++ {
+			if r.src[i] == '*' && r.src[i+1] == '/' {
+				return i + 2, nil
+			}
+		}
+		return 0, r.errorAt(start, errors.New("unterminated block comment"))
+	}
+	for i := start; i < len(r.src); i++ {
+		if r.src[i] == '\n' {
+			return i, nil
+		}
+	}
+	return len(r.src), nil
+}
+
+// blank 使用空格替换注释，保留换行
+func (r *jsonRelaxer) blank(bf []byte) {
+	for _, c := range bf {
+		if c == '\n' || c == '\r' {
+			r.out = append(r.out, c)
+		} else {
+			r.out = append(r.out, ' ')
+		}
+	}
+}
+
+// nextSignificant 返回 start 之后第一个不是空白字符也不在注释中的位置
+func (r *jsonRelaxer) nextSignificant(start int) int {
+	i := start
+	for i < len(r.src) {
+		if isJSONSpace(r.src[i]) {
+			i++
+			continue
+		}
+		if r.isCommentStart(i) {
+			end, err := r.commentEnd(i)
+			if err != nil {
+				return len(r.src)
+			}
+			i = end
+			continue
+		}
+		return i
+	}
+	return i
+}
+
+func (r *jsonRelaxer) errorAt(offset int, err error) error {
+	line, column := OffsetPosition(r.src, offset)
+	return &PositionError{Line: line, Column: column, Err: err}
+}
+
+func isJSONSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,70 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package parser
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fsgo/fst"
+)
+
+func TestJSON_relaxed(t *testing.T) {
+	txt := `# head comment
+{
+  // line comment
+  "url": "http://a.com/#x", # trailing comment
+  "path": "/*not comment*/", /* block
+  comment */
+  "list": [1, 2, 3,],
+  "obj": {"a": "b",},
+}
+`
+	var got map[string]any
+	fst.NoError(t, JSON([]byte(txt), &got))
+	fst.Equal[any](t, "http://a.com/#x", got["url"])
+	fst.Equal[any](t, "/*not comment*/", got["path"])
+	fst.Len(t, got["list"].([]any), 3)
+	fst.Equal[any](t, "b", got["obj"].(map[string]any)["a"])
+}
+
+func TestJSON5(t *testing.T) {
+	txt := `{
+  name: 'it\'s "demo"',
+  $port: 80,
+  nested: {ok: true, list: ['a', "b",]},
+  "quoted": null,
+}`
+	var got map[string]any
+	fst.NoError(t, JSON5([]byte(txt), &got))
+	fst.Equal[any](t, `it's "demo"`, got["name"])
+	fst.Equal[any](t, true, got["nested"].(map[string]any)["ok"])
+	fst.Equal[any](t, []any{"a", "b"}, got["nested"].(map[string]any)["list"])
+	_, ok := got["$port"]
+	fst.True(t, ok)
+
+	// 非 JSON5 时，不支持不带引号的 key
+	fst.Error(t, JSON([]byte(txt), &got))
+}
+
+func TestJSON_relaxedError(t *testing.T) {
+	tests := []struct {
+		txt  string
+		line int
+	}{
+		{txt: "{\n/* abc", line: 2},
+		{txt: "{\n\"a\": \"b\n}", line: 2},
+		{txt: "{\n// c\n\"a\": ,\n}", line: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.txt, func(t *testing.T) {
+			var got map[string]any
+			err := JSON([]byte(tt.txt), &got)
+			var pe *PositionError
+			fst.True(t, errors.As(err, &pe))
+			fst.Equal(t, tt.line, pe.Line)
+		})
+	}
+}
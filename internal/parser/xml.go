@@ -0,0 +1,25 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package parser
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+)
+
+// ErrTreeNotSupported 配置格式不能解析为通用的数据结构（*any）
+var ErrTreeNotSupported = errors.New("cannot decode into a generic tree")
+
+// XML .xml 文件的解析方法
+//
+// xml 没有对应的通用数据结构，解析到 *any 时会返回 ErrTreeNotSupported，
+// 而不是和 xml.Unmarshal 一样，不返回错误但是也不解析任何内容
+func XML(txt []byte, obj any) error {
+	if _, ok := obj.(*any); ok {
+		return fmt.Errorf("xml: %w", ErrTreeNotSupported)
+	}
+	return xml.Unmarshal(txt, obj)
+}
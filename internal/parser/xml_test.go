@@ -0,0 +1,25 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package parser
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fsgo/fst"
+)
+
+func TestXML(t *testing.T) {
+	type config struct {
+		Name string `xml:"name"`
+	}
+	var cfg config
+	fst.NoError(t, XML([]byte(`<config><name>demo</name></config>`), &cfg))
+	fst.Equal(t, "demo", cfg.Name)
+
+	var data any
+	err := XML([]byte(`<config><name>demo</name></config>`), &data)
+	fst.True(t, errors.Is(err, ErrTreeNotSupported))
+}
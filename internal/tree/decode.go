@@ -0,0 +1,454 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package tree
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Tags 解析到 struct 时，用于查找字段名称的 tag，会依次查找，
+// 若都没有，则使用字段名（不区分大小写）
+var Tags = []string{"json", "toml", "yaml", "xml"}
+
+// Decode 将树形数据 data 解析到 obj 上，obj 必须是非 nil 的指针
+//
+// 和 encoding/json 的行为一样，data 中不存在的字段会保持 obj 原有的值，
+// 实现了 json.Unmarshaler 的类型，会将 data 编码为 JSON 后使用 UnmarshalJSON 解析。
+// 当 obj 中原有的值（如 any 类型的字段）和 data 都是 map 时，会使用 Merge 合并。
+func Decode(data any, obj any) error {
+	return DecodeWithOptions(data, obj, Options{})
+}
+
+// DecodeWithTags 和 Decode 一样，但是使用 tags 查找字段的名称，如 .ini 格式使用 "ini" tag
+func DecodeWithTags(data any, obj any, tags ...string) error {
+	return DecodeWithOptions(data, obj, Options{Tags: tags})
+}
+
+// Options 解析的选项
+type Options struct {
+	// Tags 用于查找字段名称的 tag，为空时使用 Tags
+	Tags []string
+
+	// SingleAsList 解析到 slice 或者 array 时，是否将单个的值作为只有一个元素的数组，
+	// 用于不区分单个值和数组的格式，如 .ini 中只出现一次的 key
+	SingleAsList bool
+}
+
+// DecodeWithOptions 和 Decode 一样，但是使用 opts 控制解析的行为
+func DecodeWithOptions(data any, obj any, opts Options) error {
+	rv := reflect.ValueOf(obj)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("decode: obj must be a non-nil pointer, got %T", obj)
+	}
+	tags := opts.Tags
+	if len(tags) == 0 {
+		tags = Tags
+	}
+	d := &decoder{
+		tags:         tags,
+		json:         slices.Contains(tags, "json"),
+		singleAsList: opts.SingleAsList,
+	}
+	return d.decodeValue("", data, rv.Elem())
+}
+
+type decoder struct {
+	tags []string
+
+	// json 是否使用 json tag，若是，和 encoding/json 一样优先使用 json.Unmarshaler
+	json bool
+
+	// singleAsList 是否将单个的值作为只有一个元素的数组
+	singleAsList bool
+}
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+func (d *decoder) decodeValue(path string, data any, rv reflect.Value) error {
+	if data == nil {
+		return nil
+	}
+	if err := d.decodeValueNoPath(path, data, rv); err != nil {
+		var de *Error
+		if errors.As(err, &de) {
+			return err
+		}
+		return &Error{Path: path, Err: err}
+	}
+	return nil
+}
+
+func (d *decoder) decodeValueNoPath(path string, data any, rv reflect.Value) error {
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		return d.decodeValue(path, data, rv.Elem())
+	}
+
+	if d.json && rv.CanAddr() && rv.Addr().Type().Implements(jsonUnmarshalerType) {
+		bf, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		return rv.Addr().Interface().(json.Unmarshaler).UnmarshalJSON(bf)
+	}
+
+	if rv.Kind() == reflect.Interface && rv.NumMethod() == 0 && !rv.IsNil() {
+		// 和 Merge 一样，当原有的值和 data 都是 map 时，递归合并
+		rv.Set(reflect.ValueOf(Merge(Normalize(rv.Elem().Interface()), data)))
+		return nil
+	}
+
+	if rv.CanAddr() && rv.Addr().Type().Implements(textUnmarshalerType) {
+		if str, ok := data.(string); ok {
+			return rv.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(str))
+		}
+	}
+
+	dv := reflect.ValueOf(data)
+	if rv.Kind() != reflect.Map && rv.Kind() != reflect.Slice && rv.Kind() != reflect.Struct &&
+		dv.Type().AssignableTo(rv.Type()) {
+		rv.Set(dv)
+		return nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Interface:
+		if rv.NumMethod() == 0 {
+			rv.Set(dv)
+			return nil
+		}
+	case reflect.Struct:
+		m, ok := data.(map[string]any)
+		if !ok {
+			if dv.Type().AssignableTo(rv.Type()) {
+				rv.Set(dv)
+				return nil
+			}
+			return typeError(data, rv)
+		}
+		return d.decodeStruct(path, m, rv)
+	case reflect.Map:
+		return d.decodeMap(path, data, rv)
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			if str, ok := data.(string); ok {
+				rv.SetBytes([]byte(str))
+				return nil
+			}
+		}
+		return d.decodeSlice(path, data, rv)
+	case reflect.Array:
+		return d.decodeArray(path, data, rv)
+	case reflect.String:
+		return decodeString(data, rv)
+	case reflect.Bool:
+		return decodeBool(data, rv)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return decodeInt(data, rv)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return decodeUint(data, rv)
+	case reflect.Float32, reflect.Float64:
+		return decodeFloat(data, rv)
+	}
+	return typeError(data, rv)
+}
+
+func typeError(data any, rv reflect.Value) error {
+	return fmt.Errorf("cannot decode %T into %s", data, rv.Type())
+}
+
+func (d *decoder) decodeStruct(path string, m map[string]any, rv reflect.Value) error {
+	fields := StructFieldsWithTags(rv.Type(), d.tags...)
+	for key, val := range m {
+		f := fields.Find(key)
+		if f == nil {
+			continue
+		}
+		fv, err := fieldByIndex(rv, f.Index)
+		if err != nil {
+			return err
+		}
+		if err = d.decodeValue(joinPath(path, key), val, fv); err != nil {
+			if IsSecretField(f.Field) {
+				// 错误信息中可能包含敏感的值
+				return &Error{Path: joinPath(path, key), Err: fmt.Errorf("invalid value for %s", fv.Type())}
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// fieldByIndex 和 reflect.Value.FieldByIndex 类似，但是会初始化为 nil 的嵌入结构体指针
+func fieldByIndex(rv reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 && rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				if !rv.CanSet() {
+					return reflect.Value{}, fmt.Errorf("cannot set embedded pointer to unexported struct %s", rv.Type().Elem())
+				}
+				rv.Set(reflect.New(rv.Type().Elem()))
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(x)
+	}
+	return rv, nil
+}
+
+func (d *decoder) decodeMap(path string, data any, rv reflect.Value) error {
+	m, ok := data.(map[string]any)
+	if !ok {
+		return typeError(data, rv)
+	}
+	rt := rv.Type()
+	if rv.IsNil() {
+		rv.Set(reflect.MakeMapWithSize(rt, len(m)))
+	}
+	for key, val := range m {
+		kv := reflect.New(rt.Key()).Elem()
+		if err := d.decodeValue(path, key, kv); err != nil {
+			return err
+		}
+		ev := reflect.New(rt.Elem()).Elem()
+		if old := rv.MapIndex(kv); old.IsValid() {
+			ev.Set(old)
+		}
+		if err := d.decodeValue(joinPath(path, key), val, ev); err != nil {
+			return err
+		}
+		rv.SetMapIndex(kv, ev)
+	}
+	return nil
+}
+
+func (d *decoder) decodeSlice(path string, data any, rv reflect.Value) error {
+	arr, ok := d.toList(data)
+	if !ok {
+		return typeError(data, rv)
+	}
+	sv := reflect.MakeSlice(rv.Type(), len(arr), len(arr))
+	for i, item := range arr {
+		if err := d.decodeValue(joinIndex(path, i), item, sv.Index(i)); err != nil {
+			return err
+		}
+	}
+	rv.Set(sv)
+	return nil
+}
+
+func (d *decoder) decodeArray(path string, data any, rv reflect.Value) error {
+	arr, ok := d.toList(data)
+	if !ok {
+		return typeError(data, rv)
+	}
+	if len(arr) > rv.Len() {
+		return fmt.Errorf("array length %d exceeds %s", len(arr), rv.Type())
+	}
+	for i, item := range arr {
+		if err := d.decodeValue(joinIndex(path, i), item, rv.Index(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toList 将 data 转换为数组，开启了 singleAsList 时，单个的值会作为只有一个元素的数组
+func (d *decoder) toList(data any) ([]any, bool) {
+	switch v := data.(type) {
+	case []any:
+		return v, true
+	case map[string]any:
+		return nil, false
+	}
+	if d.singleAsList {
+		return []any{data}, true
+	}
+	return nil, false
+}
+
+func decodeString(data any, rv reflect.Value) error {
+	switch v := data.(type) {
+	case string:
+		rv.SetString(v)
+	case json.Number:
+		rv.SetString(v.String())
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		rv.SetString(fmt.Sprint(v))
+	default:
+		return typeError(data, rv)
+	}
+	return nil
+}
+
+func decodeBool(data any, rv reflect.Value) error {
+	switch v := data.(type) {
+	case bool:
+		rv.SetBool(v)
+	case string:
+		b, err := strconv.ParseBool(strings.TrimSpace(v))
+		if err != nil {
+			return err
+		}
+		rv.SetBool(b)
+	default:
+		return typeError(data, rv)
+	}
+	return nil
+}
+
+func decodeInt(data any, rv reflect.Value) error {
+	if rv.Type() == durationType {
+		if str, ok := data.(string); ok {
+			d, err := time.ParseDuration(strings.TrimSpace(str))
+			if err != nil {
+				return err
+			}
+			rv.SetInt(int64(d))
+			return nil
+		}
+	}
+	var n int64
+	switch v := data.(type) {
+	case string:
+		var err error
+		n, err = strconv.ParseInt(strings.TrimSpace(v), 0, 64)
+		if err != nil {
+			return err
+		}
+	case json.Number:
+		var err error
+		n, err = v.Int64()
+		if err != nil {
+			return err
+		}
+	case float32, float64:
+		f := reflect.ValueOf(v).Float()
+		if f != float64(int64(f)) {
+			return fmt.Errorf("cannot decode float %v into %s", v, rv.Type())
+		}
+		n = int64(f)
+	case int, int8, int16, int32, int64:
+		n = reflect.ValueOf(v).Int()
+	case uint, uint8, uint16, uint32, uint64:
+		n = int64(reflect.ValueOf(v).Uint())
+	default:
+		return typeError(data, rv)
+	}
+	if rv.OverflowInt(n) {
+		return fmt.Errorf("value %d overflows %s", n, rv.Type())
+	}
+	rv.SetInt(n)
+	return nil
+}
+
+func decodeUint(data any, rv reflect.Value) error {
+	var n uint64
+	switch v := data.(type) {
+	case string:
+		var err error
+		n, err = strconv.ParseUint(strings.TrimSpace(v), 0, 64)
+		if err != nil {
+			return err
+		}
+	case json.Number:
+		var err error
+		n, err = strconv.ParseUint(v.String(), 10, 64)
+		if err != nil {
+			return err
+		}
+	case float32, float64:
+		f := reflect.ValueOf(v).Float()
+		if f < 0 || f != float64(uint64(f)) {
+			return fmt.Errorf("cannot decode float %v into %s", v, rv.Type())
+		}
+		n = uint64(f)
+	case int, int8, int16, int32, int64:
+		i := reflect.ValueOf(v).Int()
+		if i < 0 {
+			return fmt.Errorf("cannot decode negative %d into %s", i, rv.Type())
+		}
+		n = uint64(i)
+	case uint, uint8, uint16, uint32, uint64:
+		n = reflect.ValueOf(v).Uint()
+	default:
+		return typeError(data, rv)
+	}
+	if rv.OverflowUint(n) {
+		return fmt.Errorf("value %d overflows %s", n, rv.Type())
+	}
+	rv.SetUint(n)
+	return nil
+}
+
+func decodeFloat(data any, rv reflect.Value) error {
+	var f float64
+	switch v := data.(type) {
+	case string:
+		var err error
+		f, err = strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return err
+		}
+	case json.Number:
+		var err error
+		f, err = v.Float64()
+		if err != nil {
+			return err
+		}
+	case float32, float64:
+		f = reflect.ValueOf(v).Float()
+	case int, int8, int16, int32, int64:
+		f = float64(reflect.ValueOf(v).Int())
+	case uint, uint8, uint16, uint32, uint64:
+		f = float64(reflect.ValueOf(v).Uint())
+	default:
+		return typeError(data, rv)
+	}
+	rv.SetFloat(f)
+	return nil
+}
+
+func joinPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func joinIndex(path string, i int) string {
+	return path + "[" + strconv.Itoa(i) + "]"
+}
+
+// Error 解析失败时的错误，包含出错字段的路径
+type Error struct {
+	Err  error
+	Path string // 如 DB.Port、Hosts[0].IP
+}
+
+func (e *Error) Error() string {
+	if e.Path == "" {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("decode %q: %s", e.Path, e.Err.Error())
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,159 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package tree
+
+import (
+	"encoding/json"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fsgo/fst"
+)
+
+type testBase struct {
+	ID int
+}
+
+type testDB struct {
+	Host    string
+	Port    uint16
+	Timeout time.Duration
+}
+
+type testConfig struct {
+	testBase
+	Name    string `json:"name"`
+	Ignore  string `toml:"-"`
+	Enable  bool
+	Rate    float64
+	IP      net.IP
+	DB      testDB
+	Backup  *testDB
+	Hosts   []testDB
+	Labels  map[string]string
+	Groups  map[string]*testDB
+	Extra   any
+	private int
+}
+
+func TestDecode(t *testing.T) {
+	data := map[string]any{
+		"id":     json.Number("1"),
+		"name":   "demo",
+		"Ignore": "ignored",
+		"enable": "true",
+		"Rate":   int64(2),
+		"IP":     "127.0.0.1",
+		"DB": map[string]any{
+			"Host":    "db1",
+			"Port":    "3306",
+			"Timeout": "3s",
+		},
+		"Backup": map[string]any{
+			"Port":    float64(3307),
+			"Timeout": int64(100),
+		},
+		"Hosts": []any{
+			map[string]any{"Host": "h1"},
+		},
+		"Labels": map[string]any{
+			"a": 1,
+		},
+		"Groups": map[string]any{
+			"g1": map[string]any{"Host": "g1-host"},
+		},
+		"Extra":    []any{"x"},
+		"private":  1,
+		"NotFound": 1,
+	}
+	cfg := &testConfig{
+		Labels: map[string]string{"old": "v"},
+	}
+	fst.NoError(t, Decode(data, cfg))
+	want := &testConfig{
+		testBase: testBase{ID: 1},
+		Name:     "demo",
+		Enable:   true,
+		Rate:     2,
+		IP:       net.ParseIP("127.0.0.1"),
+		DB:       testDB{Host: "db1", Port: 3306, Timeout: 3 * time.Second},
+		Backup:   &testDB{Port: 3307, Timeout: 100},
+		Hosts:    []testDB{{Host: "h1"}},
+		Labels:   map[string]string{"old": "v", "a": "1"},
+		Groups:   map[string]*testDB{"g1": {Host: "g1-host"}},
+		Extra:    []any{"x"},
+	}
+	fst.Equal(t, want, cfg)
+}
+
+func TestDecode_error(t *testing.T) {
+	var cfg testConfig
+	err := Decode(map[string]any{"DB": map[string]any{"Port": "abc"}}, &cfg)
+	fst.Error(t, err)
+	fst.Contains(t, err.Error(), `"DB.Port"`)
+
+	err = Decode(map[string]any{"Hosts": []any{map[string]any{"Port": 70000}}}, &cfg)
+	fst.Contains(t, err.Error(), `"Hosts[0].Port"`)
+
+	fst.Error(t, Decode(map[string]any{}, cfg))
+	fst.Error(t, Decode(map[string]any{"DB": "abc"}, &cfg))
+	fst.Error(t, Decode(map[string]any{"Hosts": map[string]any{"Host": "h1"}}, &cfg))
+}
+
+func TestDecode_scalarToSlice(t *testing.T) {
+	var cfg struct {
+		Hosts []string
+		Ports []int
+	}
+	data := map[string]any{"Hosts": "a", "Ports": "80"}
+	fst.Error(t, Decode(data, &cfg))
+
+	fst.NoError(t, DecodeWithOptions(data, &cfg, Options{SingleAsList: true}))
+	fst.Equal(t, []string{"a"}, cfg.Hosts)
+	fst.Equal(t, []int{80}, cfg.Ports)
+
+	var arr struct{ Ports [2]int }
+	fst.Error(t, Decode(data, &arr))
+	fst.NoError(t, DecodeWithOptions(data, &arr, Options{SingleAsList: true}))
+	fst.Equal(t, [2]int{80, 0}, arr.Ports)
+}
+
+type testLower string
+
+func (s *testLower) UnmarshalJSON(bf []byte) error {
+	var str string
+	if err := json.Unmarshal(bf, &str); err != nil {
+		return err
+	}
+	*s = testLower(strings.ToLower(str))
+	return nil
+}
+
+func TestDecode_jsonUnmarshaler(t *testing.T) {
+	var cfg struct {
+		Name testLower
+	}
+	fst.NoError(t, Decode(map[string]any{"Name": "ABC"}, &cfg))
+	fst.Equal(t, testLower("abc"), cfg.Name)
+
+	// 不使用 json tag 时，不会使用 UnmarshalJSON
+	fst.NoError(t, DecodeWithTags(map[string]any{"Name": "DEF"}, &cfg, "ini"))
+	fst.Equal(t, testLower("DEF"), cfg.Name)
+}
+
+func TestDecode_mergeAny(t *testing.T) {
+	var data any = map[string]any{
+		"A":  "a",
+		"DB": map[string]any{"Host": "h1", "Port": 1},
+	}
+	fst.NoError(t, Decode(map[string]any{"DB": map[string]any{"Port": 2}}, &data))
+	want := map[string]any{
+		"A":  "a",
+		"DB": map[string]any{"Host": "h1", "Port": 2},
+	}
+	fst.Equal[any](t, want, data)
+}
@@ -0,0 +1,127 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package tree
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// Encode 将 obj 转换为 map[string]any、[]any 和基础类型组成的树，是 Decode 的逆操作
+//
+// struct 的字段名称使用 tags 查找，若都没有，则使用字段名；
+// 实现了 encoding.TextMarshaler 的类型以及 time.Duration 会转换为字符串；nil 的指针、map、slice 会被忽略
+func Encode(obj any, tags ...string) (any, error) {
+	if len(tags) == 0 {
+		tags = Tags
+	}
+	return encodeValue(reflect.ValueOf(obj), tags)
+}
+
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+func encodeValue(rv reflect.Value, tags []string) (any, error) {
+	if !rv.IsValid() {
+		return nil, nil
+	}
+	if rv.Type().Implements(textMarshalerType) && (rv.Kind() != reflect.Ptr || !rv.IsNil()) {
+		bf, err := rv.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		return string(bf), nil
+	}
+	if rv.Type() == durationType {
+		return time.Duration(rv.Int()).String(), nil
+	}
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return nil, nil
+		}
+		return encodeValue(rv.Elem(), tags)
+	case reflect.Struct:
+		result := map[string]any{}
+		for _, f := range StructFieldsWithTags(rv.Type(), tags...) {
+			fv, ok := fieldByIndexNoAlloc(rv, f.Index)
+			if !ok {
+				continue
+			}
+			v, err := encodeValue(fv, tags)
+			if err != nil {
+				return nil, &Error{Path: f.Name, Err: err}
+			}
+			if v != nil {
+				result[f.Name] = v
+			}
+		}
+		return result, nil
+	case reflect.Map:
+		if rv.IsNil() {
+			return nil, nil
+		}
+		result := make(map[string]any, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			key := fmt.Sprint(iter.Key().Interface())
+			v, err := encodeValue(iter.Value(), tags)
+			if err != nil {
+				return nil, &Error{Path: key, Err: err}
+			}
+			if v != nil {
+				result[key] = v
+			}
+		}
+		return result, nil
+	case reflect.Slice:
+		if rv.IsNil() {
+			return nil, nil
+		}
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return string(rv.Bytes()), nil
+		}
+		fallthrough
+	case reflect.Array:
+		result := make([]any, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			v, err := encodeValue(rv.Index(i), tags)
+			if err != nil {
+				return nil, &Error{Path: fmt.Sprintf("[%d]", i), Err: err}
+			}
+			result = append(result, v)
+		}
+		return result, nil
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer, reflect.Complex64, reflect.Complex128:
+		return nil, fmt.Errorf("unsupported type %s", rv.Type())
+	}
+	return rv.Interface(), nil
+}
+
+// fieldByIndexNoAlloc 和 reflect.Value.FieldByIndex 类似，当嵌入的结构体指针为 nil 时返回 false
+func fieldByIndexNoAlloc(rv reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				return reflect.Value{}, false
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(x)
+	}
+	return rv, true
+}
+
+// SortedKeys 返回 map 排序后的 key
+func SortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,65 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package tree
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/fsgo/fst"
+)
+
+func TestEncode(t *testing.T) {
+	type Base struct {
+		ID int
+	}
+	type config struct {
+		*Base
+		Name    string `json:"name"`
+		Timeout time.Duration
+		IP      net.IP
+		Data    []byte
+		Ports   []int
+		Labels  map[string]string
+		Next    *config
+		ignored string
+	}
+	cfg := config{
+		Base:    &Base{ID: 1},
+		Name:    "demo",
+		Timeout: time.Second,
+		IP:      net.ParseIP("127.0.0.1"),
+		Data:    []byte("abc"),
+		Ports:   []int{80, 443},
+		Labels:  map[string]string{"a": "b"},
+		ignored: "x",
+	}
+	got, err := Encode(cfg)
+	fst.NoError(t, err)
+	want := map[string]any{
+		"ID":      1,
+		"name":    "demo",
+		"Timeout": "1s",
+		"IP":      "127.0.0.1",
+		"Data":    "abc",
+		"Ports":   []any{80, 443},
+		"Labels":  map[string]any{"a": "b"},
+	}
+	fst.Equal[any](t, want, got)
+
+	cfg.Base = nil
+	got, err = Encode(&cfg)
+	fst.NoError(t, err)
+	delete(want, "ID")
+	fst.Equal[any](t, want, got)
+
+	_, err = Encode(map[string]any{"f": func() {}})
+	fst.Error(t, err)
+}
+
+func TestSortedKeys(t *testing.T) {
+	fst.Equal(t, []string{"a", "b", "c"}, SortedKeys(map[string]any{"c": 1, "a": 2, "b": 3}))
+}
@@ -0,0 +1,133 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package tree
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Field struct 的一个可导出字段
+type Field struct {
+	Field reflect.StructField
+	Name  string // 配置中使用的名称，由 Tags 或者字段名决定
+	Index []int  // 可用于 reflect.Value.FieldByIndex，已展开匿名嵌入的结构体
+}
+
+// Fields struct 所有可导出字段
+type Fields []*Field
+
+// Find 查找 key 对应的字段，优先完全匹配，其次不区分大小写匹配
+func (fs Fields) Find(key string) *Field {
+	for _, f := range fs {
+		if f.Name == key {
+			return f
+		}
+	}
+	for _, f := range fs {
+		if strings.EqualFold(f.Name, key) {
+			return f
+		}
+	}
+	return nil
+}
+
+var fieldsCache sync.Map // map[fieldsCacheKey]Fields
+
+type fieldsCacheKey struct {
+	rt   reflect.Type
+	tags string
+}
+
+// StructFields 返回 struct 类型所有可导出的字段，匿名嵌入的结构体字段会被展开
+func StructFields(rt reflect.Type) Fields {
+	return StructFieldsWithTags(rt, Tags...)
+}
+
+// StructFieldsWithTags 和 StructFields 一样，但是使用 tags 查找字段的名称
+func StructFieldsWithTags(rt reflect.Type, tags ...string) Fields {
+	key := fieldsCacheKey{rt: rt, tags: strings.Join(tags, ",")}
+	if v, ok := fieldsCache.Load(key); ok {
+		return v.(Fields)
+	}
+	fs := structFields(rt, nil, tags)
+	fieldsCache.Store(key, fs)
+	return fs
+}
+
+func structFields(rt reflect.Type, index []int, tags []string) Fields {
+	var result Fields
+	var embedded Fields
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		name, skip := fieldName(sf, tags)
+		if skip {
+			continue
+		}
+		idx := append(append([]int{}, index...), i)
+		ft := sf.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if sf.Anonymous && ft.Kind() == reflect.Struct && name == "" {
+			embedded = append(embedded, structFields(ft, idx, tags)...)
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		result = append(result, &Field{
+			Field: sf,
+			Name:  name,
+			Index: idx,
+		})
+	}
+	// 和 encoding/json 一样，外层的字段优先于嵌入结构体中的同名字段
+	for _, f := range embedded {
+		if result.Find(f.Name) == nil {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// KeyName 返回字段在配置中使用的名称，和 Decode 的规则一致：优先使用 Tags 中的名称，否则使用字段名
+func KeyName(sf reflect.StructField) string {
+	if name, _ := fieldName(sf, Tags); name != "" {
+		return name
+	}
+	return sf.Name
+}
+
+// fieldName 从 tags 中读取字段的名称，若 tag 值为 "-" 则应跳过该字段
+func fieldName(sf reflect.StructField, tags []string) (name string, skip bool) {
+	for _, tag := range tags {
+		v, ok := sf.Tag.Lookup(tag)
+		if !ok {
+			continue
+		}
+		name, _, _ = strings.Cut(v, ",")
+		if name == "-" {
+			return "", true
+		}
+		if name != "" {
+			return name, false
+		}
+	}
+	return "", false
+}
+
+// SecretTag 用于标记敏感字段的 struct tag 名称
+const SecretTag = "secret"
+
+// IsSecretField 字段是否有 secret tag，且值不为 "false"
+func IsSecretField(sf reflect.StructField) bool {
+	v, ok := sf.Tag.Lookup(SecretTag)
+	return ok && v != "false"
+}
@@ -0,0 +1,76 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+// Package tree 处理由各种格式的配置解析出的通用数据结构（由 map[string]any、[]any 和基础类型组成的树）
+package tree
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Normalize 将解析得到的数据统一为 map[string]any、[]any 和基础类型组成的树
+//
+// 如 yaml 会解析出 map[any]any，toml 会解析出 []map[string]any 等
+func Normalize(v any) any {
+	if v == nil {
+		return nil
+	}
+	switch vv := v.(type) {
+	case map[string]any:
+		for k, item := range vv {
+			vv[k] = Normalize(item)
+		}
+		return vv
+	case []any:
+		for i, item := range vv {
+			vv[i] = Normalize(item)
+		}
+		return vv
+	case []byte:
+		return vv
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Map:
+		m := make(map[string]any, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			m[fmt.Sprint(iter.Key().Interface())] = Normalize(iter.Value().Interface())
+		}
+		return m
+	case reflect.Slice, reflect.Array:
+		arr := make([]any, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			arr[i] = Normalize(rv.Index(i).Interface())
+		}
+		return arr
+	default:
+		return v
+	}
+}
+
+// Merge 将 src 深度合并到 dst 上，并返回合并后的结果
+//
+// 只有当 dst 和 src 都是 map[string]any 时才会递归合并，其他情况 src 会覆盖 dst。
+// dst 和 src 都不会被修改。
+func Merge(dst any, src any) any {
+	dm, ok1 := dst.(map[string]any)
+	sm, ok2 := src.(map[string]any)
+	if !ok1 || !ok2 {
+		return src
+	}
+	result := make(map[string]any, len(dm)+len(sm))
+	for k, v := range dm {
+		result[k] = v
+	}
+	for k, v := range sm {
+		if old, has := result[k]; has {
+			result[k] = Merge(old, v)
+		} else {
+			result[k] = v
+		}
+	}
+	return result
+}
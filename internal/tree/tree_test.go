@@ -0,0 +1,55 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package tree
+
+import (
+	"testing"
+
+	"github.com/fsgo/fst"
+)
+
+func TestNormalize(t *testing.T) {
+	got := Normalize(map[any]any{
+		"a": []map[string]any{{"b": 1}},
+		1:   map[string]int{"c": 2},
+	})
+	want := map[string]any{
+		"a": []any{map[string]any{"b": 1}},
+		"1": map[string]any{"c": 2},
+	}
+	fst.Equal[any](t, want, got)
+}
+
+func TestMerge(t *testing.T) {
+	dst := map[string]any{
+		"A": 1,
+		"DB": map[string]any{
+			"Host": "127.0.0.1",
+			"Port": 3306,
+		},
+		"List": []any{1, 2},
+	}
+	src := map[string]any{
+		"B": 2,
+		"DB": map[string]any{
+			"Port": 3307,
+		},
+		"List": []any{3},
+	}
+	want := map[string]any{
+		"A": 1,
+		"B": 2,
+		"DB": map[string]any{
+			"Host": "127.0.0.1",
+			"Port": 3307,
+		},
+		"List": []any{3},
+	}
+	fst.Equal[any](t, want, Merge(dst, src))
+	fst.Equal(t, 3306, dst["DB"].(map[string]any)["Port"])
+
+	fst.Equal[any](t, "b", Merge(map[string]any{"a": 1}, "b"))
+	fst.Equal[any](t, nil, Merge(nil, nil))
+}
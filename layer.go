@@ -0,0 +1,103 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/fsgo/fsconf/internal/parser"
+	"github.com/fsgo/fsconf/internal/tree"
+)
+
+// ParseLayers 依次读取多个配置文件，深度合并后再解析到 obj 上，后面的文件会覆盖前面文件中的同名配置。
+//
+// 每个文件都会先经过 Hook 处理，再使用其文件后缀对应的 parser 解析为通用的数据结构（map[string]any），
+// 所以每个文件可以使用不同的格式，如 app.json + app.local.toml。
+// 只有当两边都是 map 时才会递归合并，其他类型（包括数组）都是直接覆盖。
+// Validator 和 AutoCheck 只会对最终的结果执行一次。
+//
+// confNames 中的第一个文件是必须存在的，其后的文件若不存在会被跳过。
+// 由于需要解析为通用的数据结构，不支持 .xml 格式，会返回 ErrTreeNotSupported。
+func (c *Configure) ParseLayers(obj any, confNames ...string) error {
+	if len(confNames) == 0 {
+		return errors.New("confNames is empty")
+	}
+	var merged any
+	for i, confName := range confNames {
+		data, found, err := c.readLayer(confName, i > 0)
+		if err != nil {
+			return c.redactError(err)
+		}
+		if !found {
+			continue
+		}
+		merged = tree.Merge(merged, data)
+	}
+	return c.redactError(c.decodeTree(merged, obj))
+}
+
+// readLayer 读取一个配置文件，并解析为通用的数据结构，
+// 当 optional=true 且文件不存在时，返回 found=false
+func (c *Configure) readLayer(confName string, optional bool) (data any, found bool, err error) {
+	if len(c.parsers) == 0 {
+		return nil, false, errors.New("no parser")
+	}
+	confAbsPath, err := c.confFileAbsPath(confName)
+	if err != nil {
+		return nil, false, err
+	}
+	return c.readLayerByAbsPath(confAbsPath, optional)
+}
+
+func (c *Configure) readLayerByAbsPath(confAbsPath string, optional bool) (data any, found bool, err error) {
+	if c.dropInDir(confAbsPath) {
+		data, err = c.readDirLayer(confAbsPath)
+	} else {
+		data, err = c.readFileLayer(confAbsPath)
+	}
+	if err != nil {
+		if optional && errors.Is(err, fs.ErrNotExist) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return data, true, nil
+}
+
+func (c *Configure) readFileLayer(confAbsPath string) (any, error) {
+	realFile, fileExt, content, err := c.readConfFile(confAbsPath)
+	if err != nil {
+		return nil, err
+	}
+	return c.decodeLayer(realFile, fileExt, content)
+}
+
+// decodeLayer 将配置内容解析为通用的数据结构
+func (c *Configure) decodeLayer(realFile string, fileExt string, content []byte) (any, error) {
+	var data any
+	if err := c.decodeBytes(realFile, fileExt, content, &data); err != nil {
+		return nil, fmt.Errorf("parser %q failed: %w", realFile, err)
+	}
+	if data == nil && len(parser.StripComment(content)) > 0 {
+		// 如 xml.Unmarshal 解析到 *any 时，不会返回错误，但是也不会解析任何内容
+		return nil, fmt.Errorf("parser %q failed: fileExt %q %w", realFile, fileExt, ErrTreeNotSupported)
+	}
+	return tree.Normalize(data), nil
+}
+
+// decodeTree 将合并后的通用数据结构解析到 obj 上，并执行校验
+func (c *Configure) decodeTree(data any, obj any) error {
+	if err := tree.Decode(data, obj); err != nil {
+		var te *tree.Error
+		if errors.As(err, &te) && c.redactor().IsSecretKey(te.Path) {
+			// 错误信息中可能包含敏感的值
+			return fmt.Errorf("decode %q: invalid value", te.Path)
+		}
+		return err
+	}
+	return c.afterDecode(obj)
+}
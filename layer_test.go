@@ -0,0 +1,99 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fsgo/fst"
+)
+
+type testLayerConfig struct {
+	Name  string
+	DB    testLayerDB
+	Hosts []string
+
+	checkTimes int
+}
+
+type testLayerDB struct {
+	Host string
+	Port int
+}
+
+func (tc *testLayerConfig) AutoCheck() error {
+	tc.checkTimes++
+	if tc.DB.Port == 0 {
+		return errors.New("DB.Port is required")
+	}
+	return nil
+}
+
+func TestParseLayers(t *testing.T) {
+	t.Run("merge", func(t *testing.T) {
+		var cfg testLayerConfig
+		err := ParseLayers(&cfg, "layer/app.json", "layer/app.local", "layer/not_exists.json")
+		fst.NoError(t, err)
+		want := testLayerConfig{
+			Name: "app",
+			DB: testLayerDB{
+				Host: "127.0.0.1",
+				Port: 3307,
+			},
+			Hosts:      []string{"c"},
+			checkTimes: 1,
+		}
+		fst.Equal(t, want, cfg)
+	})
+
+	t.Run("map", func(t *testing.T) {
+		var cfg map[string]any
+		fst.NoError(t, ParseLayers(&cfg, "layer/app.json", "layer/app.local.json"))
+		fst.Equal[any](t, "127.0.0.1", cfg["DB"].(map[string]any)["Host"])
+	})
+
+	t.Run("base not exists", func(t *testing.T) {
+		var cfg testLayerConfig
+		fst.Error(t, ParseLayers(&cfg, "layer/not_exists.json", "layer/app.json"))
+	})
+
+	t.Run("no names", func(t *testing.T) {
+		var cfg testLayerConfig
+		fst.Error(t, ParseLayers(&cfg))
+	})
+
+	t.Run("xml not supported", func(t *testing.T) {
+		dir := t.TempDir()
+		fp := filepath.Join(dir, "app.xml")
+		fst.NoError(t, os.WriteFile(fp, []byte(`<config><Name>app</Name></config>`), 0644))
+		var cfg testLayerConfig
+		err := ParseLayers(&cfg, fp)
+		fst.True(t, errors.Is(err, ErrTreeNotSupported))
+	})
+
+	t.Run("nil tree", func(t *testing.T) {
+		dir := t.TempDir()
+		fp := filepath.Join(dir, "app.nil")
+		fst.NoError(t, os.WriteFile(fp, []byte("Name=app"), 0644))
+		c := NewDefault()
+		fst.NoError(t, c.RegisterParser(".nil", func(bf []byte, obj any) error {
+			return nil
+		}))
+		var cfg testLayerConfig
+		err := c.ParseLayers(&cfg, fp)
+		fst.True(t, errors.Is(err, ErrTreeNotSupported))
+	})
+	t.Run("single value into slice", func(t *testing.T) {
+		dir := t.TempDir()
+		fp := filepath.Join(dir, "app.json")
+		fst.NoError(t, os.WriteFile(fp, []byte(`{"Ports":8080}`), 0644))
+		var cfg struct{ Ports []int }
+		fst.Error(t, Parse(fp, &cfg))
+		fst.Error(t, ParseLayers(&cfg, fp))
+	})
+}
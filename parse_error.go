@@ -0,0 +1,271 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/fsgo/fsconf/internal/parser"
+)
+
+// ParseError 配置内容解析（DecoderFunc）失败时的错误
+//
+// 会尽可能的将出错的位置，从经过 Hook 处理后的内容，映射回原始文件（或者 include 的文件）上
+type ParseError struct {
+	// Err 原始的错误
+	Err error
+
+	// Path 出错位置所在的文件，当直接解析内容（如 ParseBytes）时，为空字符串
+	Path string
+
+	// Line 出错的行号，从 1 开始，为 0 时表示未知
+	Line int
+
+	// Column 出错的列号，从 1 开始，为 0 时表示未知
+	Column int
+
+	// Rendered 为 true 时，表示未能映射回原始文件，Line 和 Column 是在经过 Hook 处理后的内容中的位置
+	Rendered bool
+
+	// Frame 出错位置附近的几行内容，其中的敏感内容已被隐藏
+	Frame string
+
+	content []byte
+	redact  func(string) string
+}
+
+func (e *ParseError) Error() string {
+	var b strings.Builder
+	b.WriteString("parse")
+	if e.Path != "" {
+		b.WriteString(" " + e.Path)
+	}
+	if e.Line > 0 {
+		b.WriteString(":" + strconv.Itoa(e.Line))
+		if e.Column > 0 {
+			b.WriteString(":" + strconv.Itoa(e.Column))
+		}
+		if e.Rendered {
+			b.WriteString(" (rendered)")
+		}
+	}
+	b.WriteString(": ")
+	if e.redact != nil {
+		b.WriteString(e.redact(e.Err.Error()))
+	} else {
+		b.WriteString(DefaultRedactor.RedactText(e.Err.Error()))
+	}
+	if e.Frame != "" {
+		b.WriteString("\n")
+		b.WriteString(e.Frame)
+	}
+	return b.String()
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Content 返回经过 Hook 处理后、传给 DecoderFunc 的完整内容，
+// 其中可能包含密码等敏感内容，请勿直接打印到日志中
+func (e *ParseError) Content() []byte {
+	return e.content
+}
+
+// 如 "toml: line 3 (last key ...)"、"yaml: line 3: ..."、"line 3, column 5: ..."
+var errLineReg = regexp.MustCompile(`\bline (\d+)(?:(?:,| \(|:)? ?col(?:umn)? (\d+))?`)
+
+// errorPosition 从 DecoderFunc 返回的错误中读取出错的行号和列号
+func errorPosition(err error) (line int, column int) {
+	var pe *parser.PositionError
+	if errors.As(err, &pe) {
+		return pe.Line, pe.Column
+	}
+	var xe *xml.SyntaxError
+	if errors.As(err, &xe) {
+		return xe.Line, 0
+	}
+	m := errLineReg.FindStringSubmatch(err.Error())
+	if len(m) == 0 {
+		return 0, 0
+	}
+	line, _ = strconv.Atoi(m[1])
+	if m[2] != "" {
+		column, _ = strconv.Atoi(m[2])
+	}
+	return line, column
+}
+
+// newParseError 创建 ParseError
+//
+//	confPath: 配置文件路径
+//	content: 配置文件的原始内容
+//	rendered: 经过 Hook 处理后的内容
+//	includes: Hook 处理过程中读取的其他文件
+func (c *Configure) newParseError(confPath string, content []byte, rendered []byte, includes []string, err error) *ParseError {
+	pe := &ParseError{
+		Err:     err,
+		Path:    confPath,
+		content: rendered,
+		redact:  c.redactText,
+	}
+	line, column := errorPosition(err)
+	if line <= 0 {
+		return pe
+	}
+	renderedLines := splitLines(rendered)
+	if line > len(renderedLines) {
+		pe.Line, pe.Column, pe.Rendered = line, column, true
+		return pe
+	}
+	src := c.locateLine(confPath, content, renderedLines, line, includes)
+	if src == nil {
+		pe.Line, pe.Column, pe.Rendered = line, column, true
+		pe.Frame = c.redactText(codeFrame(renderedLines, line, column))
+		return pe
+	}
+	pe.Path = src.path
+	pe.Line = src.line
+	pe.Column = column
+	if column > 0 {
+		pe.Column = mapColumn(renderedLines[line-1], src.lines[src.line-1], column)
+	}
+	pe.Frame = c.redactText(codeFrame(src.lines, pe.Line, pe.Column))
+	return pe
+}
+
+type sourceLine struct {
+	path  string
+	lines []string
+	line  int
+}
+
+// locateLine 查找经过 Hook 处理后的内容的第 line 行，在原始文件中的位置
+func (c *Configure) locateLine(confPath string, content []byte, rendered []string, line int, includes []string) *sourceLine {
+	origLines := splitLines(content)
+	// 行数一样，说明只是行内的替换，如 {osenv.xxx}
+	if len(origLines) == len(rendered) {
+		return &sourceLine{path: confPath, lines: origLines, line: line}
+	}
+	if idx := findLine(origLines, rendered, line); idx > 0 {
+		return &sourceLine{path: confPath, lines: origLines, line: idx}
+	}
+	for _, fp := range includes {
+		if fp == confPath {
+			continue
+		}
+		bf, err := c.readFile(fp)
+		if err != nil {
+			continue
+		}
+		lines := splitLines(bf)
+		if idx := findLine(lines, rendered, line); idx > 0 {
+			return &sourceLine{path: fp, lines: lines, line: idx}
+		}
+	}
+	return nil
+}
+
+// findLine 在 lines 中查找和 rendered 第 line 行相同的行，
+// 若有多个，则再比较其前后一行，只有当唯一匹配时才返回，返回的行号从 1 开始，找不到时返回 0
+func findLine(lines []string, rendered []string, line int) int {
+	target := strings.TrimSpace(rendered[line-1])
+	if target == "" {
+		return 0
+	}
+	var candidates []int
+	for i, l := range lines {
+		if strings.TrimSpace(l) == target {
+			candidates = append(candidates, i)
+		}
+	}
+	if len(candidates) == 1 {
+		return candidates[0] + 1
+	}
+	sameAt := func(i int, j int) bool {
+		if i < 0 || i >= len(lines) || j < 0 || j >= len(rendered) {
+			return false
+		}
+		return strings.TrimSpace(lines[i]) == strings.TrimSpace(rendered[j])
+	}
+	var found []int
+	for _, i := range candidates {
+		if sameAt(i-1, line-2) && sameAt(i+1, line) {
+			found = append(found, i)
+		}
+	}
+	if len(found) == 1 {
+		return found[0] + 1
+	}
+	return 0
+}
+
+// mapColumn 当行内容仅缩进不同时，修正列号
+func mapColumn(rendered string, src string, column int) int {
+	if rendered == src {
+		return column
+	}
+	if strings.TrimSpace(rendered) != strings.TrimSpace(src) {
+		return column
+	}
+	diff := (len(rendered) - len(strings.TrimLeft(rendered, " \t"))) - (len(src) - len(strings.TrimLeft(src, " \t")))
+	if c := column - diff; c > 0 {
+		return c
+	}
+	return column
+}
+
+func splitLines(content []byte) []string {
+	return strings.Split(string(bytes.TrimSuffix(content, []byte("\n"))), "\n")
+}
+
+// codeFrameLines 错误信息中，出错位置前后展示的行数
+const codeFrameLines = 2
+
+// codeFrame 返回出错位置附近的内容，如：
+//
+//	  2 |   "a": 1,
+//	> 3 |   "b": ,
+//	    |        ^
+//	  4 | }
+func codeFrame(lines []string, line int, column int) string {
+	if line <= 0 || line > len(lines) {
+		return ""
+	}
+	start := max(line-codeFrameLines, 1)
+	end := min(line+codeFrameLines, len(lines))
+	width := len(strconv.Itoa(end))
+	var b strings.Builder
+	for i := start; i <= end; i++ {
+		mark := "  "
+		if i == line {
+			mark = "> "
+		}
+		fmt.Fprintf(&b, "%s%*d | %s\n", mark, width, i, strings.TrimRight(lines[i-1], "\r"))
+		if i == line && column > 0 {
+			fmt.Fprintf(&b, "  %s | %s^\n", strings.Repeat(" ", width), caretPadding(lines[i-1], column))
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// caretPadding 返回 ^ 之前的填充内容，保留 tab 以便对齐
+func caretPadding(line string, column int) string {
+	var b strings.Builder
+	for i := 0; i < column-1 && i < len(line); i++ {
+		if line[i] == '\t' {
+			b.WriteByte('\t')
+		} else {
+			b.WriteByte(' ')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,78 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/fsgo/fst"
+)
+
+func TestParseError(t *testing.T) {
+	t.Run("parse bytes", func(t *testing.T) {
+		content := "# comment\n{\n  \"A\": \"a\",\n  \"B\": ,\n  \"C\": \"c\"\n}\n"
+		var obj map[string]string
+		err := ParseBytes(".json", []byte(content), &obj)
+		var pe *ParseError
+		fst.True(t, errors.As(err, &pe))
+		fst.Equal(t, "", pe.Path)
+		fst.Equal(t, 4, pe.Line)
+		fst.Equal(t, 8, pe.Column)
+		fst.False(t, pe.Rendered)
+		wantFrame := "  2 | {\n  3 |   \"A\": \"a\",\n> 4 |   \"B\": ,\n    |        ^\n  5 |   \"C\": \"c\"\n  6 | }"
+		fst.Equal(t, wantFrame, pe.Frame)
+		fst.Equal(t, content, string(pe.Content()))
+		fst.NotContains(t, err.Error(), "# comment")
+	})
+
+	t.Run("include", func(t *testing.T) {
+		var obj map[string]string
+		err := Parse("parse_error/include.json", &obj)
+		var pe *ParseError
+		fst.True(t, errors.As(err, &pe))
+		fst.Equal(t, filepath.Join("testdata", "conf", "parse_error", "sub", "b.json"), pe.Path)
+		fst.Equal(t, 2, pe.Line)
+		fst.Equal(t, 9, pe.Column)
+		fst.Contains(t, err.Error(), "b.json:2:9")
+	})
+
+	t.Run("osenv", func(t *testing.T) {
+		var obj map[string]any
+		err := Parse("parse_error/osenv.json", &obj)
+		var pe *ParseError
+		fst.True(t, errors.As(err, &pe))
+		fst.Equal(t, filepath.Join("testdata", "conf", "parse_error", "osenv.json"), pe.Path)
+		fst.Equal(t, 3, pe.Line)
+		fst.Contains(t, pe.Frame, `> 3 |   "Name": "{osenv.APP}" x`)
+		fst.Contains(t, string(pe.Content()), "demo.fenji")
+	})
+	t.Run("user created", func(t *testing.T) {
+		pe := &ParseError{Err: errors.New(`invalid value password="abc"`), Path: "app.json", Line: 2}
+		fst.Equal(t, `parse app.json:2: invalid value password="******"`, pe.Error())
+	})
+}
+
+func Test_errorPosition(t *testing.T) {
+	tests := []struct {
+		msg    string
+		line   int
+		column int
+	}{
+		{msg: `toml: line 3 (last key "a"): expected value`, line: 3},
+		{msg: "yaml: line 5: did not find expected key", line: 5},
+		{msg: "line 2, column 3: bad", line: 2, column: 3},
+		{msg: "toml: line 4, column 7: bad", line: 4, column: 7},
+		{msg: "something wrong"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.msg, func(t *testing.T) {
+			line, column := errorPosition(errors.New(tt.msg))
+			fst.Equal(t, tt.line, line)
+			fst.Equal(t, tt.column, column)
+		})
+	}
+}
@@ -5,8 +5,6 @@
 package fsconf
 
 import (
-	"encoding/xml"
-
 	"github.com/fsgo/fsconf/internal/parser"
 )
 
@@ -20,8 +18,22 @@ type parserNameFn struct {
 }
 
 // defaultParsers 所有默认的 parser，
-// 当传入配置文件名不包含后置的时候，会使用此顺序依次查找
+// 当传入配置文件名不包含后置的时候，会使用此顺序依次查找（.env 除外，见 explicitExts）
 var defaultParsers = []parserNameFn{
 	{Name: ".json", Fn: parser.JSON},
-	{Name: ".xml", Fn: xml.Unmarshal},
+	{Name: ".xml", Fn: parser.XML},
+	{Name: ".ini", Fn: parser.INI},
+	{Name: ".env", Fn: parser.DotEnv},
+	{Name: ".properties", Fn: parser.Properties},
+	{Name: ".jsonc", Fn: parser.JSON},
+	{Name: ".json5", Fn: parser.JSON5},
 }
+
+// INITag 解析 .ini 文件到 struct 时，用于查找字段名称的 tag，若没有，则使用字段名（不区分大小写）
+const INITag = parser.INITag
+
+// ErrTreeNotSupported 配置格式（如 .xml）不能解析为通用的数据结构时返回的错误，
+// 如 ParseLayers、ParseDir 以及 SetProfileOverlay 的 overlay 文件等都需要解析为通用的数据结构。
+//
+// 自定义的 DecoderFunc 在不支持解析到 *any 时，也应返回此错误
+var ErrTreeNotSupported = parser.ErrTreeNotSupported
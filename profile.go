@@ -0,0 +1,83 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fsgo/fsconf/internal/tree"
+)
+
+// SetProfileOverlay 设置是否自动合并 RunMode 和 IDC 对应的配置文件，默认不开启。
+//
+// 开启后，如 Parse("app.toml") 时，会依次查找如下文件，若存在则合并到 app.toml 之上，
+// 越靠后的优先级越高：
+//
+//	app.{RunMode}.toml       如 app.product.toml
+//	app.{IDC}.toml           如 app.bj.toml
+//	app.{IDC}.{RunMode}.toml 如 app.bj.product.toml
+//
+// 优先查找和 app.toml 相同后缀的文件，若不存在，会再按照已注册 parser 的顺序查找，
+// 所以也可以使用不同的格式，如 app.bj.json。
+// app.toml 总是使用自身的 parser 解析，overlay 文件的合并规则和 ParseLayers 相同，
+// 需要能够解析为通用的数据结构（如不支持 .xml 格式），当不存在任何 overlay 文件时，和未开启时的行为一致。
+func (c *Configure) SetProfileOverlay(enable bool) {
+	c.profileOverlay = enable
+}
+
+// profileNames 返回需要合并的 profile 名称，按照优先级从低到高排列
+func (c *Configure) profileNames() []string {
+	env := c.appEnv()
+	runMode := env.RunMode().String()
+	idc := env.IDC()
+	names := []string{runMode}
+	if idc != "" {
+		names = append(names, idc, idc+"."+runMode)
+	}
+	return names
+}
+
+// profileFiles 返回 realFile 对应的、已存在的 profile 文件
+func (c *Configure) profileFiles(realFile string, fileExt string) []string {
+	stem := strings.TrimSuffix(realFile, fileExt)
+	var result []string
+	for _, name := range c.profileNames() {
+		if fp, ok := c.findProfileFile(stem+"."+name, fileExt); ok {
+			result = append(result, fp)
+		}
+	}
+	return result
+}
+
+func (c *Configure) findProfileFile(stem string, fileExt string) (string, bool) {
+	exts := append([]string{fileExt}, c.extensions()...)
+	for _, ext := range exts {
+		fp := stem + ext
+		// 即使文件不存在也记录下来，以便 Watch 能够发现新增的文件
+		trackFile(c.context(), fp)
+		if c.fileExists(fp) {
+			return fp, true
+		}
+	}
+	return "", false
+}
+
+// parseWithOverlays 先使用 realFile 自身的 parser 解析到 obj 上，再将 overlays 合并到 obj 上，
+// 以保证 realFile 的解析结果和不存在 overlay 文件时一致（如 .xml 格式、实现了 json.Unmarshaler 的字段）
+func (c *Configure) parseWithOverlays(realFile string, fileExt string, content []byte, overlays []string, obj any) error {
+	if err := c.decodeBytes(realFile, fileExt, content, obj); err != nil {
+		return fmt.Errorf("parser %q failed: %w", realFile, err)
+	}
+	var merged any
+	for _, fp := range overlays {
+		data, _, err := c.readLayerByAbsPath(fp, false)
+		if err != nil {
+			return err
+		}
+		merged = tree.Merge(merged, data)
+	}
+	return c.decodeTree(merged, obj)
+}
@@ -0,0 +1,117 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fsgo/fsenv"
+	"github.com/fsgo/fst"
+)
+
+func TestConfigure_SetProfileOverlay(t *testing.T) {
+	defer func(idc string, mode fsenv.Mode) {
+		fsenv.SetIDC(idc)
+		fsenv.SetRunMode(mode)
+	}(fsenv.IDC(), fsenv.RunMode())
+
+	fsenv.SetRunMode(fsenv.ModeProduct)
+
+	parse := func(t *testing.T, enable bool) map[string]string {
+		c := NewDefault()
+		c.SetProfileOverlay(enable)
+		var got map[string]string
+		fst.NoError(t, c.Parse("profile/app.json", &got))
+		return got
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		fsenv.SetIDC("bj")
+		want := map[string]string{"A": "base", "B": "base", "C": "base", "D": "base"}
+		fst.Equal(t, want, parse(t, false))
+	})
+
+	t.Run("idc bj", func(t *testing.T) {
+		fsenv.SetIDC("bj")
+		want := map[string]string{"A": "base", "B": "product", "C": "bj", "D": "bj.product"}
+		fst.Equal(t, want, parse(t, true))
+	})
+
+	t.Run("idc without overlay", func(t *testing.T) {
+		fsenv.SetIDC("sh")
+		want := map[string]string{"A": "base", "B": "product", "C": "product", "D": "product"}
+		fst.Equal(t, want, parse(t, true))
+	})
+
+	t.Run("no overlay", func(t *testing.T) {
+		fsenv.SetIDC("bj")
+		c := NewDefault()
+		c.SetProfileOverlay(true)
+		var got map[string]string
+		fst.NoError(t, c.Parse("abc.json", &got))
+		fst.Equal(t, map[string]string{"A": "bb"}, got)
+	})
+}
+
+type testUpperString string
+
+func (s *testUpperString) UnmarshalJSON(bf []byte) error {
+	var str string
+	if err := json.Unmarshal(bf, &str); err != nil {
+		return err
+	}
+	*s = testUpperString(strings.ToUpper(str))
+	return nil
+}
+
+func TestConfigure_SetProfileOverlay_base(t *testing.T) {
+	defer func(mode fsenv.Mode) {
+		fsenv.SetRunMode(mode)
+	}(fsenv.RunMode())
+	fsenv.SetRunMode(fsenv.ModeProduct)
+
+	c := NewDefault()
+	c.SetProfileOverlay(true)
+
+	t.Run("xml base", func(t *testing.T) {
+		dir := t.TempDir()
+		fst.NoError(t, os.WriteFile(filepath.Join(dir, "app.xml"), []byte(`<config><A>base</A><B>base</B></config>`), 0644))
+		fst.NoError(t, os.WriteFile(filepath.Join(dir, "app.product.json"), []byte(`{"B":"product"}`), 0644))
+		type config struct {
+			A string
+			B string
+		}
+		var got config
+		fst.NoError(t, c.Parse(filepath.Join(dir, "app.xml"), &got))
+		fst.Equal(t, config{A: "base", B: "product"}, got)
+	})
+
+	t.Run("xml overlay", func(t *testing.T) {
+		dir := t.TempDir()
+		fst.NoError(t, os.WriteFile(filepath.Join(dir, "app.xml"), []byte(`<config><A>base</A></config>`), 0644))
+		fst.NoError(t, os.WriteFile(filepath.Join(dir, "app.product.xml"), []byte(`<config><A>product</A></config>`), 0644))
+		var got struct{ A string }
+		err := c.Parse(filepath.Join(dir, "app.xml"), &got)
+		fst.True(t, errors.Is(err, ErrTreeNotSupported))
+	})
+
+	t.Run("json.Unmarshaler", func(t *testing.T) {
+		dir := t.TempDir()
+		fst.NoError(t, os.WriteFile(filepath.Join(dir, "app.json"), []byte(`{"A":"abc","B":"base"}`), 0644))
+		fst.NoError(t, os.WriteFile(filepath.Join(dir, "app.product.json"), []byte(`{"B":"def"}`), 0644))
+		type config struct {
+			A testUpperString
+			B testUpperString
+		}
+		var got config
+		fst.NoError(t, c.Parse(filepath.Join(dir, "app.json"), &got))
+		fst.Equal(t, config{A: "ABC", B: "DEF"}, got)
+	})
+}
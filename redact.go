@@ -0,0 +1,207 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/fsgo/fsconf/internal/tree"
+)
+
+// SecretTag 用于标记敏感字段的 struct tag 名称，如 `secret:"true"`，
+// 敏感字段的值不会出现在错误信息、Dump 等输出中
+const SecretTag = tree.SecretTag
+
+// Redactor 用于从错误信息和调试输出中隐藏敏感内容
+type Redactor struct {
+	// Keys 敏感的 key 名称，不区分大小写，只要 key 包含其中之一，就认为是敏感的，
+	// 如 "password" 能匹配 "DBPassword"、"db_password"
+	Keys []string
+
+	// Mask 替换敏感内容的字符串，为空时使用 "******"
+	Mask string
+}
+
+// DefaultRedactor 默认的 Redactor
+var DefaultRedactor = &Redactor{
+	Keys: []string{"password", "passwd", "pwd", "token", "secret", "credential", "private_key", "privatekey", "api_key", "apikey"},
+}
+
+func (r *Redactor) mask() string {
+	if r.Mask == "" {
+		return "******"
+	}
+	return r.Mask
+}
+
+// IsSecretKey 判断 key 名称是否是敏感的，key 可以是 a.b.c 格式的路径，
+// 只要有一段是敏感的即返回 true
+func (r *Redactor) IsSecretKey(key string) bool {
+	if r == nil || key == "" {
+		return false
+	}
+	key = strings.ToLower(key)
+	for _, k := range r.Keys {
+		if k != "" && strings.Contains(key, strings.ToLower(k)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSecretField 判断字段是否是敏感的：有 secret tag 或者字段名是敏感的
+func (r *Redactor) isSecretField(sf reflect.StructField) bool {
+	if tree.IsSecretField(sf) {
+		return true
+	}
+	return r.IsSecretKey(sf.Name)
+}
+
+// 如 password = "abc"、"token": "abc"、secret: abc
+var secretTextReg = regexp.MustCompile(`(["']?)([A-Za-z0-9_.\-]+)(["']?[ \t]*[:=][ \t]*)("(?:[^"\\\n]|\\.)*"|'[^'\n]*'|[^\s,;}\]]+)`)
+
+// RedactText 隐藏文本中形如 key=value、key: value 的敏感内容
+func (r *Redactor) RedactText(text string) string {
+	if r == nil {
+		return text
+	}
+	return secretTextReg.ReplaceAllStringFunc(text, func(s string) string {
+		m := secretTextReg.FindStringSubmatch(s)
+		if !r.IsSecretKey(m[2]) {
+			return s
+		}
+		value := m[4]
+		mask := r.mask()
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') {
+			mask = value[:1] + mask + value[:1]
+		}
+		return m[1] + m[2] + m[3] + mask
+	})
+}
+
+// Redact 返回 obj 的通用数据结构（map[string]any 等）副本，其中的敏感值会被替换为 Mask
+func (r *Redactor) Redact(obj any) (any, error) {
+	bf, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var data any
+	if err = json.Unmarshal(bf, &data); err != nil {
+		return nil, err
+	}
+	secrets := map[string]bool{}
+	secretPaths(reflect.TypeOf(obj), "", secrets, 0)
+	return r.redactTree("", data, secrets), nil
+}
+
+// secretPaths 查找所有有 secret tag 的字段，字段名和 encoding/json 的规则一致，
+// map 的 key 使用 "*" 表示，slice 的元素使用 "[]" 表示，如 Groups.*.Password、Hosts[].Token
+func secretPaths(rt reflect.Type, path string, result map[string]bool, depth int) {
+	// 避免递归类型导致的死循环
+	if rt == nil || depth > 32 {
+		return
+	}
+	switch rt.Kind() {
+	case reflect.Ptr:
+		secretPaths(rt.Elem(), path, result, depth+1)
+	case reflect.Slice, reflect.Array:
+		secretPaths(rt.Elem(), path+"[]", result, depth+1)
+	case reflect.Map:
+		secretPaths(rt.Elem(), joinKeyPath(path, "*"), result, depth+1)
+	case reflect.Struct:
+		for i := 0; i < rt.NumField(); i++ {
+			sf := rt.Field(i)
+			name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+			if sf.Anonymous && name == "" {
+				secretPaths(sf.Type, path, result, depth+1)
+				continue
+			}
+			if !sf.IsExported() {
+				continue
+			}
+			if name == "" {
+				name = sf.Name
+			}
+			fp := joinKeyPath(path, name)
+			if tree.IsSecretField(sf) {
+				result[fp] = true
+				continue
+			}
+			secretPaths(sf.Type, fp, result, depth+1)
+		}
+	}
+}
+
+func joinKeyPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func (r *Redactor) redactTree(path string, data any, secrets map[string]bool) any {
+	switch v := data.(type) {
+	case map[string]any:
+		for k, item := range v {
+			p := joinKeyPath(path, k)
+			if !secrets[p] {
+				// 若是 map 类型，则使用通配的路径
+				if wp := joinKeyPath(path, "*"); r.hasPrefixPath(secrets, wp) {
+					p = wp
+				}
+			}
+			if r.IsSecretKey(k) || secrets[p] {
+				v[k] = r.mask()
+				continue
+			}
+			v[k] = r.redactTree(p, item, secrets)
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = r.redactTree(path+"[]", item, secrets)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func (r *Redactor) hasPrefixPath(secrets map[string]bool, path string) bool {
+	for p := range secrets {
+		if p == path || strings.HasPrefix(p, path+".") || strings.HasPrefix(p, path+"[]") {
+			return true
+		}
+	}
+	return false
+}
+
+// SetRedactor 设置用于隐藏敏感内容的 Redactor，为 nil 时使用 DefaultRedactor
+func (c *Configure) SetRedactor(r *Redactor) {
+	c.redact = r
+}
+
+func (c *Configure) redactor() *Redactor {
+	if c.redact != nil {
+		return c.redact
+	}
+	return DefaultRedactor
+}
+
+// Dump 将配置对象以 JSON 格式输出，用于调试，敏感的值会被隐藏
+func (c *Configure) Dump(obj any) ([]byte, error) {
+	data, err := c.redactor().Redact(obj)
+	if err != nil {
+		return nil, err
+	}
+	data = c.secrets.redactTree(data, c.redactor().mask())
+	return json.MarshalIndent(data, "", "  ")
+}
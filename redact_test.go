@@ -0,0 +1,131 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/fsgo/fst"
+)
+
+func TestRedactor_RedactText(t *testing.T) {
+	r := DefaultRedactor
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: `password = "abc"`, want: `password = "******"`},
+		{in: `"DBPassword": "abc",`, want: `"DBPassword": "******",`},
+		{in: `token: abc`, want: `token: ******`},
+		{in: `name = "abc"`, want: `name = "abc"`},
+		{in: `{"user":"u","secret":'s'}`, want: `{"user":"u","secret":'******'}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			fst.Equal(t, tt.want, r.RedactText(tt.in))
+		})
+	}
+}
+
+type testRedactConfig struct {
+	Name     string
+	Password string
+	Key      string `json:"key" secret:"true"`
+	DB       map[string]testRedactDB
+	Hosts    []testRedactDB
+}
+
+type testRedactDB struct {
+	Host string
+	Auth string `secret:"true"`
+}
+
+func TestConfigure_Dump(t *testing.T) {
+	cfg := &testRedactConfig{
+		Name:     "demo",
+		Password: "p1",
+		Key:      "k1",
+		DB:       map[string]testRedactDB{"a": {Host: "h1", Auth: "a1"}},
+		Hosts:    []testRedactDB{{Host: "h2", Auth: "a2"}},
+	}
+	bf, err := NewDefault().Dump(cfg)
+	fst.NoError(t, err)
+	want := `{
+  "DB": {
+    "a": {
+      "Auth": "******",
+      "Host": "h1"
+    }
+  },
+  "Hosts": [
+    {
+      "Auth": "******",
+      "Host": "h2"
+    }
+  ],
+  "Name": "demo",
+  "Password": "******",
+  "key": "******"
+}`
+	fst.Equal(t, want, string(bf))
+}
+
+func TestRedact_errors(t *testing.T) {
+	t.Run("parse error", func(t *testing.T) {
+		content := "{\n  \"Name\": \"demo\",\n  \"Password\": \"p@ss\",\n  \"Port\": abc\n}"
+		var obj map[string]any
+		err := ParseBytes(".json", []byte(content), &obj)
+		var pe *ParseError
+		fst.True(t, errors.As(err, &pe))
+		fst.NotContains(t, err.Error(), "p@ss")
+		fst.Contains(t, err.Error(), `"Password": "******"`)
+		fst.Contains(t, string(pe.Content()), "p@ss")
+	})
+
+	t.Run("layers", func(t *testing.T) {
+		var obj struct {
+			Token int
+		}
+		c := NewDefault()
+		err := c.decodeTree(map[string]any{"Token": "my-token"}, &obj)
+		fst.Error(t, err)
+		fst.NotContains(t, err.Error(), "my-token")
+	})
+
+	t.Run("secret tag", func(t *testing.T) {
+		var obj struct {
+			Key int `secret:"true"`
+		}
+		c := NewDefault()
+		err := c.decodeTree(map[string]any{"Key": "my-key"}, &obj)
+		fst.Error(t, err)
+		fst.NotContains(t, err.Error(), "my-key")
+	})
+
+	t.Run("env", func(t *testing.T) {
+		t.Setenv("APP_TOKEN", "my-token")
+		var obj struct {
+			Token int `env:"TOKEN"`
+		}
+		c := NewDefault()
+		c.SetEnvOverride(&EnvOverride{Prefix: "APP"})
+		err := c.ParseBytes(".json", []byte(`{}`), &obj)
+		fst.Error(t, err)
+		fst.Contains(t, err.Error(), "APP_TOKEN")
+		fst.NotContains(t, err.Error(), "my-token")
+	})
+
+	t.Run("flags", func(t *testing.T) {
+		cfg := &testRedactConfig{Password: "p1"}
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		fst.NoError(t, BindFlags(fs, cfg, ""))
+		fst.Equal(t, "******", fs.Lookup("password").DefValue)
+		fst.Equal(t, "", fs.Lookup("key").DefValue)
+	})
+}
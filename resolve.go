@@ -0,0 +1,78 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// SetExtensionOrder 设置配置文件名不包含后缀时，查找文件使用的后缀顺序，
+// 未在 exts 中的后缀，按照 parser 注册的顺序排在后面，但是 .env 只有在 exts 中时才会查找
+func (c *Configure) SetExtensionOrder(exts ...string) {
+	c.extOrder = exts
+}
+
+// SetStrictExtension 设置是否使用严格模式查找配置文件，
+// 严格模式下，若配置文件名不包含后缀，且同时存在多个后缀的文件（如 db.json 和 db.toml），
+// Parse 会返回 *AmbiguousPathError，Exists 会返回 false
+func (c *Configure) SetStrictExtension(strict bool) {
+	c.strictExt = strict
+}
+
+// AmbiguousPathError 严格模式下，配置文件名匹配到多个文件时返回的错误
+type AmbiguousPathError struct {
+	Path       string   // 不包含后缀的配置文件路径
+	Candidates []string // 匹配到的所有文件，按照查找的顺序
+}
+
+func (e *AmbiguousPathError) Error() string {
+	return fmt.Sprintf("ambiguous config %q, candidates: %s", e.Path, strings.Join(e.Candidates, ", "))
+}
+
+// explicitExts 只在配置文件名中明确指定时才使用的后缀，配置文件名不包含后缀时不会查找，
+// 如 .env 一般用于存放环境变量，以免 Parse("app") 时读取到 app.env，可以使用 SetExtensionOrder 启用
+var explicitExts = []string{".env"}
+
+// extensions 返回查找配置文件时使用的后缀，先是 extOrder 中已注册的，之后是其他已注册的（explicitExts 除外）
+func (c *Configure) extensions() []string {
+	result := make([]string, 0, len(c.parseNames))
+	for _, ext := range c.extOrder {
+		if _, has := c.parsers[ext]; has && !slices.Contains(result, ext) {
+			result = append(result, ext)
+		}
+	}
+	for _, ext := range c.parseNames {
+		if !slices.Contains(result, ext) && !slices.Contains(explicitExts, ext) {
+			result = append(result, ext)
+		}
+	}
+	return result
+}
+
+// probeExt 依次添加后缀查找 confPath 对应的文件，
+// 严格模式下，会查找所有的后缀，当存在多个文件时返回 *AmbiguousPathError
+func (c *Configure) probeExt(confPath string) (path string, ext string, found bool, err error) {
+	var candidates []string
+	for _, ext2 := range c.extensions() {
+		name2 := confPath + ext2
+		info2, err2 := c.statFile(name2)
+		if err2 != nil || info2.IsDir() {
+			continue
+		}
+		if !c.strictExt {
+			return name2, ext2, true, nil
+		}
+		if len(candidates) == 0 {
+			path, ext = name2, ext2
+		}
+		candidates = append(candidates, name2)
+	}
+	if len(candidates) > 1 {
+		return "", "", false, &AmbiguousPathError{Path: confPath, Candidates: candidates}
+	}
+	return path, ext, len(candidates) == 1, nil
+}
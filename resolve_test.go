@@ -0,0 +1,80 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"testing/fstest"
+
+	"github.com/fsgo/fst"
+)
+
+func TestConfigure_SetExtensionOrder(t *testing.T) {
+	fsys := fstest.MapFS{
+		"db.json": {Data: []byte(`{"Name":"json"}`)},
+		"db.ini":  {Data: []byte("Name = ini\n")},
+		"app.ini": {Data: []byte("Name = app\n")},
+	}
+	type config struct {
+		Name string
+	}
+	conf := NewDefault().WithFS(fsys)
+
+	var got config
+	fst.NoError(t, conf.Parse("db", &got))
+	fst.Equal(t, "json", got.Name)
+
+	conf.SetExtensionOrder(".toml", ".ini")
+	fst.Equal(t, ".ini", conf.extensions()[0])
+	fst.Equal(t, ".json", conf.extensions()[1])
+	fst.Len(t, conf.extensions(), len(conf.Parsers())-1)
+	fst.False(t, slices.Contains(conf.extensions(), ".env"))
+	fst.NoError(t, conf.Parse("db", &got))
+	fst.Equal(t, "ini", got.Name)
+
+	t.Run("strict", func(t *testing.T) {
+		c1 := conf.Clone()
+		c1.SetStrictExtension(true)
+		err := c1.Parse("db", &got)
+		var ae *AmbiguousPathError
+		fst.True(t, errors.As(err, &ae))
+		fst.Equal(t, []string{"db.ini", "db.json"}, ae.Candidates)
+		fst.Contains(t, err.Error(), "db.ini, db.json")
+		fst.False(t, c1.Exists("db"))
+
+		// 指定了后缀，或者只有一个文件时，不受影响
+		fst.NoError(t, c1.Parse("db.json", &got))
+		fst.Equal(t, "json", got.Name)
+		fst.True(t, c1.Exists("db.json"))
+		fst.NoError(t, c1.Parse("app", &got))
+		fst.Equal(t, "app", got.Name)
+		fst.True(t, c1.Exists("app"))
+		fst.False(t, c1.Exists("not_found"))
+	})
+}
+
+func TestConfigure_explicitExt(t *testing.T) {
+	fsys := fstest.MapFS{
+		"app.env": {Data: []byte("NAME=env\n")},
+	}
+	type config struct {
+		Name string
+	}
+	conf := NewDefault().WithFS(fsys)
+	var got config
+	fst.False(t, conf.Exists("app"))
+	fst.Error(t, conf.Parse("app", &got))
+
+	fst.True(t, conf.Exists("app.env"))
+	fst.NoError(t, conf.Parse("app.env", &got))
+	fst.Equal(t, "env", got.Name)
+
+	conf.SetExtensionOrder(".env")
+	got = config{}
+	fst.NoError(t, conf.Parse("app", &got))
+	fst.Equal(t, "env", got.Name)
+}
@@ -0,0 +1,185 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"errors"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/fsgo/fsconf/internal/tree"
+)
+
+// SearchMode 使用 SetSearchPaths 设置了多个目录时，查找配置文件的方式
+type SearchMode int
+
+const (
+	// SearchFirstMatch 使用第一个存在此配置文件的目录
+	SearchFirstMatch SearchMode = iota
+
+	// SearchMergeAll 读取所有目录中的此配置文件，深度合并后再解析，后面目录中的配置覆盖前面的
+	SearchMergeAll
+)
+
+// SetSearchPaths 设置查找配置文件的目录，按照顺序查找，如：
+//
+//	SetSearchPaths("./conf", "$HOME/.config/app", "/etc/app")
+//
+// 目录中的环境变量会被展开，相对路径是相对于当前的工作目录（使用 WithFS 时，是 fsys 中的路径）。
+// 设置后，不再从 fsenv.ConfDir() 和当前目录查找，以 "./"、"../" 开头的或者绝对路径的配置文件名不受影响。
+// 可以使用 Locate 查看实际使用的配置文件。
+func (c *Configure) SetSearchPaths(paths ...string) {
+	c.searchPaths = paths
+}
+
+// SetSearchMode 设置查找配置文件的方式，默认为 SearchFirstMatch
+//
+// 使用 SearchMergeAll 时，和 ParseLayers 一样需要将配置解析为通用的数据结构，不支持 .xml 格式。
+// 若开启了 SetProfileOverlay，每个目录中的配置文件都会先合并其 overlay 文件，再和其他目录的合并。
+func (c *Configure) SetSearchMode(mode SearchMode) {
+	c.searchMode = mode
+}
+
+// Locate 返回 confName 实际使用的配置文件（或者 conf.d 目录），
+// 使用 SearchMergeAll 或者开启了 SetProfileOverlay 时，可能会有多个文件，按照合并的顺序，最后一个优先级最高
+func (c *Configure) Locate(confName string) ([]string, error) {
+	if cands := c.searchCandidates(confName); len(cands) > 0 {
+		files, err := c.locateFiles(cands)
+		if err != nil {
+			return nil, err
+		}
+		if len(files) == 0 {
+			_, _, err = c.realConfPath(cands[0])
+			return nil, err
+		}
+		return c.withProfileFiles(files), nil
+	}
+	p, err := c.confFileAbsPath(confName)
+	if err != nil {
+		return nil, err
+	}
+	if c.dropInDir(p) {
+		return []string{p}, nil
+	}
+	realFile, _, err := c.realConfPath(p)
+	if err != nil {
+		return nil, err
+	}
+	return c.withProfileFiles([]string{realFile}), nil
+}
+
+// withProfileFiles 开启了 SetProfileOverlay 时，在每个配置文件之后添加其已存在的 overlay 文件
+func (c *Configure) withProfileFiles(files []string) []string {
+	if !c.profileOverlay {
+		return files
+	}
+	result := make([]string, 0, len(files))
+	for _, fp := range files {
+		result = append(result, fp)
+		if !c.dropInDir(fp) {
+			result = append(result, c.profileFiles(fp, filepath.Ext(fp))...)
+		}
+	}
+	return result
+}
+
+// searchCandidates 返回 confName 在所有查找目录中的路径，未设置查找目录时返回 nil
+func (c *Configure) searchCandidates(confName string) []string {
+	if len(c.searchPaths) == 0 || strings.HasPrefix(confName, "./") || strings.HasPrefix(confName, "../") {
+		return nil
+	}
+	result := make([]string, 0, len(c.searchPaths))
+	for _, dir := range c.searchPaths {
+		dir = os.ExpandEnv(dir)
+		if c.fsys != nil {
+			if strings.HasPrefix(confName, "/") {
+				return nil
+			}
+			if fp, err := c.fsPath(path.Join(dir, confName)); err == nil {
+				result = append(result, fp)
+			}
+			continue
+		}
+		if filepath.IsAbs(confName) {
+			return nil
+		}
+		fp, err := filepath.Abs(filepath.Join(dir, confName))
+		if err == nil {
+			result = append(result, fp)
+		}
+	}
+	return result
+}
+
+// locateFiles 返回 cands 中存在的配置文件，使用 SearchFirstMatch 时最多只返回一个
+func (c *Configure) locateFiles(cands []string) ([]string, error) {
+	var files []string
+	for _, fp := range cands {
+		c.trackCandidate(fp)
+		if c.dropInDir(fp) {
+			files = append(files, fp)
+			if c.searchMode != SearchMergeAll {
+				break
+			}
+			continue
+		}
+		realFile, _, err := c.realConfPath(fp)
+		if err != nil {
+			var ae *AmbiguousPathError
+			if errors.As(err, &ae) {
+				return nil, err
+			}
+			continue
+		}
+		files = append(files, realFile)
+		if c.searchMode != SearchMergeAll {
+			break
+		}
+	}
+	return files, nil
+}
+
+// trackCandidate 记录可能的配置文件，以便 Watch 能够发现新增的文件
+func (c *Configure) trackCandidate(fp string) {
+	ctx := c.context()
+	if fileTrackerFromContext(ctx) == nil {
+		return
+	}
+	if _, has := c.parsers[filepath.Ext(fp)]; has {
+		trackFile(ctx, fp)
+		return
+	}
+	for _, ext := range c.extensions() {
+		trackFile(ctx, fp+ext)
+	}
+}
+
+// searchFileAbsPath 在查找目录中查找配置文件，返回优先级最高的文件，
+// 若都不存在，返回第一个目录中的路径
+func (c *Configure) searchFileAbsPath(cands []string) (string, error) {
+	files, err := c.locateFiles(cands)
+	if err != nil {
+		return "", err
+	}
+	if len(files) == 0 {
+		return cands[0], nil
+	}
+	return files[len(files)-1], nil
+}
+
+// parseMerged 依次读取多个配置文件（或者 conf.d 目录）以及它们的 overlay 文件，深度合并后再解析到 obj 上
+func (c *Configure) parseMerged(files []string, obj any) error {
+	var merged any
+	for _, fp := range c.withProfileFiles(files) {
+		data, _, err := c.readLayerByAbsPath(fp, false)
+		if err != nil {
+			return err
+		}
+		merged = tree.Merge(merged, data)
+	}
+	return c.decodeTree(merged, obj)
+}
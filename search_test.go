@@ -0,0 +1,148 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/fsgo/fsenv"
+	"github.com/fsgo/fst"
+)
+
+func TestConfigure_SetSearchPaths(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, content string) string {
+		fp := filepath.Join(dir, name)
+		fst.NoError(t, os.MkdirAll(filepath.Dir(fp), 0755))
+		fst.NoError(t, os.WriteFile(fp, []byte(content), 0644))
+		return fp
+	}
+	local := write("local/app.json", `{"Name":"local","DB":{"Host":"127.0.0.1"}}`)
+	home := write("home/.config/app/app.ini", "[DB]\nPort = 3306\n")
+	etc := write("etc/app/app.json", `{"Name":"etc","DB":{"Host":"10.0.0.1","Port":3307}}`)
+	write("etc/app/only_etc.json", `{"Name":"only_etc"}`)
+
+	t.Setenv("FSCONF_TEST_HOME", filepath.Join(dir, "home"))
+
+	type config struct {
+		Name string
+		DB   struct {
+			Host string
+			Port int
+		}
+	}
+
+	conf := NewDefault()
+	conf.SetSearchPaths(filepath.Join(dir, "local"), "$FSCONF_TEST_HOME/.config/app", filepath.Join(dir, "etc/app"))
+
+	t.Run("first match", func(t *testing.T) {
+		var got config
+		fst.NoError(t, conf.Parse("app", &got))
+		fst.Equal(t, "local", got.Name)
+		fst.Equal(t, 0, got.DB.Port)
+
+		files, err := conf.Locate("app")
+		fst.NoError(t, err)
+		fst.Equal(t, []string{local}, files)
+
+		fst.True(t, conf.Exists("only_etc"))
+		fst.NoError(t, conf.Parse("only_etc.json", &got))
+		fst.Equal(t, "only_etc", got.Name)
+
+		fst.False(t, conf.Exists("not_found"))
+		_, err = conf.Locate("not_found")
+		fst.Error(t, err)
+		fst.Error(t, conf.Parse("not_found", &got))
+	})
+
+	t.Run("merge all", func(t *testing.T) {
+		c1 := conf.Clone()
+		c1.SetSearchMode(SearchMergeAll)
+
+		var got config
+		fst.NoError(t, c1.Parse("app", &got))
+		fst.Equal(t, "etc", got.Name)
+		fst.Equal(t, "10.0.0.1", got.DB.Host)
+		fst.Equal(t, 3307, got.DB.Port)
+
+		files, err := c1.Locate("app")
+		fst.NoError(t, err)
+		fst.Equal(t, []string{local, home, etc}, files)
+
+		tr, err := c1.ParseWithTrace("app", &got)
+		fst.NoError(t, err)
+		fst.Equal(t, etc, tr.Get("Name").File)
+
+		var got2 config
+		fst.NoError(t, c1.Parse("only_etc", &got2))
+		fst.Equal(t, "only_etc", got2.Name)
+	})
+
+	t.Run("explicit path", func(t *testing.T) {
+		var got config
+		fst.NoError(t, conf.Parse(etc, &got))
+		fst.Equal(t, "etc", got.Name)
+	})
+
+	t.Run("fs", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"a/app.json": {Data: []byte(`{"Name":"a"}`)},
+			"b/app.json": {Data: []byte(`{"Name":"b","DB":{"Port":1}}`)},
+		}
+		c1 := NewDefault().WithFS(fsys)
+		c1.SetSearchPaths("not_found", "a", "b")
+		var got config
+		fst.NoError(t, c1.Parse("app", &got))
+		fst.Equal(t, "a", got.Name)
+
+		c1.SetSearchMode(SearchMergeAll)
+		fst.NoError(t, c1.Parse("app", &got))
+		fst.Equal(t, "b", got.Name)
+		files, err := c1.Locate("app")
+		fst.NoError(t, err)
+		fst.Equal(t, []string{"a/app.json", "b/app.json"}, files)
+	})
+}
+
+func TestConfigure_SetSearchPaths_profile(t *testing.T) {
+	defer func(idc string, mode fsenv.Mode) {
+		fsenv.SetIDC(idc)
+		fsenv.SetRunMode(mode)
+	}(fsenv.IDC(), fsenv.RunMode())
+	fsenv.SetIDC("")
+	fsenv.SetRunMode(fsenv.ModeProduct)
+
+	dir := t.TempDir()
+	write := func(name string, content string) string {
+		fp := filepath.Join(dir, name)
+		fst.NoError(t, os.MkdirAll(filepath.Dir(fp), 0755))
+		fst.NoError(t, os.WriteFile(fp, []byte(content), 0644))
+		return fp
+	}
+	a := write("a/app.json", `{"A":"a","B":"a","C":"a"}`)
+	aProduct := write("a/app.product.json", `{"B":"a.product","C":"a.product"}`)
+	b := write("b/app.json", `{"C":"b"}`)
+
+	c := NewDefault()
+	c.SetSearchPaths(filepath.Join(dir, "a"), filepath.Join(dir, "b"))
+	c.SetSearchMode(SearchMergeAll)
+	c.SetProfileOverlay(true)
+
+	var got map[string]string
+	fst.NoError(t, c.Parse("app", &got))
+	fst.Equal(t, map[string]string{"A": "a", "B": "a.product", "C": "b"}, got)
+
+	files, err := c.Locate("app")
+	fst.NoError(t, err)
+	fst.Equal(t, []string{a, aProduct, b}, files)
+
+	c.SetSearchMode(SearchFirstMatch)
+	files, err = c.Locate("app")
+	fst.NoError(t, err)
+	fst.Equal(t, []string{a, aProduct}, files)
+}
@@ -0,0 +1,306 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// ErrSecretNotFound 敏感信息不存在
+var ErrSecretNotFound = errors.New("secret not found")
+
+// SecretProvider 敏感信息（如密码）的提供者，
+// 配置中使用 {secret.{Name}:{key}} 的格式读取，如 {secret.env:DB_PASS}
+type SecretProvider interface {
+	// Name 名称，不可为空，只能包含字母、数字、下划线和中划线
+	Name() string
+
+	// GetSecret 读取 key 对应的值，不存在时应返回 ErrSecretNotFound
+	GetSecret(ctx context.Context, key string) (string, error)
+}
+
+// NewSecretProvider 使用函数创建 SecretProvider
+func NewSecretProvider(name string, fn func(ctx context.Context, key string) (string, error)) SecretProvider {
+	return &secretProviderFunc{name: name, fn: fn}
+}
+
+type secretProviderFunc struct {
+	fn   func(ctx context.Context, key string) (string, error)
+	name string
+}
+
+func (s *secretProviderFunc) Name() string {
+	return s.name
+}
+
+func (s *secretProviderFunc) GetSecret(ctx context.Context, key string) (string, error) {
+	return s.fn(ctx, key)
+}
+
+// defaultSecretProviders 默认的 SecretProvider
+var defaultSecretProviders = []SecretProvider{
+	// {secret.env:DB_PASS} 从环境变量读取
+	&envSecretProvider{},
+
+	// {secret.file:/run/secrets/db} 读取文件的内容，会去掉末尾的换行符
+	&fileSecretProvider{},
+}
+
+// confSecretProvider 需要使用 Configure 读取内容的 SecretProvider，如使用 WithFS 设置的 fs.FS，
+// 由于结果和 Configure 相关，读取到的值不会缓存
+type confSecretProvider interface {
+	getSecretFrom(ctx context.Context, c *Configure, key string) (string, error)
+}
+
+var _ confSecretProvider = (*envSecretProvider)(nil)
+
+// envSecretProvider 从环境变量读取，除了 os 的环境变量，也会读取 SetEnvFiles 设置的 .env 文件
+type envSecretProvider struct{}
+
+func (p *envSecretProvider) Name() string {
+	return "env"
+}
+
+func (p *envSecretProvider) GetSecret(ctx context.Context, key string) (string, error) {
+	return p.getSecretFrom(ctx, New(), key)
+}
+
+func (p *envSecretProvider) getSecretFrom(ctx context.Context, c *Configure, key string) (string, error) {
+	files, err := c.envFilePaths()
+	if err != nil {
+		return "", err
+	}
+	lookup, err := c.envLookup(ctx, files)
+	if err != nil {
+		return "", err
+	}
+	if v, ok := lookup(key); ok {
+		return v, nil
+	}
+	return "", ErrSecretNotFound
+}
+
+var _ confSecretProvider = (*fileSecretProvider)(nil)
+
+// fileSecretProvider 读取文件的内容，使用 WithFS 时，读取的是 fs.FS 中的文件
+type fileSecretProvider struct{}
+
+func (p *fileSecretProvider) Name() string {
+	return "file"
+}
+
+func (p *fileSecretProvider) GetSecret(ctx context.Context, key string) (string, error) {
+	return p.getSecretFrom(ctx, New(), key)
+}
+
+func (p *fileSecretProvider) getSecretFrom(_ context.Context, c *Configure, key string) (string, error) {
+	fp := key
+	if c.fsys != nil {
+		var err error
+		if fp, err = c.fsPath(key); err != nil {
+			return "", err
+		}
+	}
+	bf, err := c.readFile(fp)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", ErrSecretNotFound
+		}
+		return "", err
+	}
+	return strings.TrimRight(string(bf), "\r\n"), nil
+}
+
+// DefaultSecretCacheTTL 读取到的敏感信息默认的缓存时间
+var DefaultSecretCacheTTL = 5 * time.Minute
+
+// RegisterSecretProvider 注册 SecretProvider，若出现重名会注册失败
+func (c *Configure) RegisterSecretProvider(p SecretProvider) error {
+	name := p.Name()
+	if !secretNameReg.MatchString(name) {
+		return fmt.Errorf("invalid secret provider name %q", name)
+	}
+	if _, has := c.secretProviders[name]; has {
+		return fmt.Errorf("secret provider=%q already exists", name)
+	}
+	if c.secretProviders == nil {
+		c.secretProviders = map[string]SecretProvider{}
+	}
+	c.secretProviders[name] = p
+	return nil
+}
+
+// SetSecretCacheTTL 设置读取到的敏感信息的缓存时间，为 0 时使用 DefaultSecretCacheTTL，小于 0 时不缓存
+func (c *Configure) SetSecretCacheTTL(ttl time.Duration) {
+	c.secretTTL = ttl
+}
+
+func (c *Configure) getSecretCacheTTL() time.Duration {
+	if c.secretTTL == 0 {
+		return DefaultSecretCacheTTL
+	}
+	return c.secretTTL
+}
+
+// getSecret 读取敏感信息，优先从缓存中读取
+func (c *Configure) getSecret(ctx context.Context, provider string, key string) (string, error) {
+	p, ok := c.secretProviders[provider]
+	if !ok {
+		return "", fmt.Errorf("secret %s:%s: provider %q not registered", provider, key, provider)
+	}
+	if cp, ok := p.(confSecretProvider); ok {
+		v, err := cp.getSecretFrom(ctx, c, key)
+		if err != nil {
+			return "", fmt.Errorf("secret %s:%s: %w", provider, key, err)
+		}
+		c.secrets.set("", v, -1)
+		return v, nil
+	}
+	ttl := c.getSecretCacheTTL()
+	cacheKey := provider + ":" + key
+	if ttl > 0 {
+		if v, ok := c.secrets.get(cacheKey); ok {
+			return v, nil
+		}
+	}
+	v, err := p.GetSecret(ctx, key)
+	if err != nil {
+		return "", fmt.Errorf("secret %s:%s: %w", provider, key, err)
+	}
+	c.secrets.set(cacheKey, v, ttl)
+	return v, nil
+}
+
+// redactText 隐藏文本中的敏感内容，包括 Redactor 识别的以及通过 SecretProvider 读取到的值
+func (c *Configure) redactText(text string) string {
+	return c.secrets.redactText(c.redactor().RedactText(text), c.redactor().mask())
+}
+
+// redactError 当错误信息中包含敏感内容时，返回隐藏后的错误，原始的错误依然可以使用 errors.As 等读取
+func (c *Configure) redactError(err error) error {
+	if err == nil {
+		return nil
+	}
+	msg := err.Error()
+	msg1 := c.secrets.redactText(msg, c.redactor().mask())
+	if msg1 == msg {
+		return err
+	}
+	return &redactedError{err: err, msg: msg1}
+}
+
+type redactedError struct {
+	err error
+	msg string
+}
+
+func (e *redactedError) Error() string {
+	return e.msg
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// minSecretRedactLen 在文本中隐藏敏感信息时，值的最小长度，避免过短的值（如 "1"）误伤其他内容
+const minSecretRedactLen = 4
+
+// secretStore 敏感信息的缓存，以及所有读取过的值（用于隐藏）
+//
+// 由 Clone 后的多个 Configure 共享
+type secretStore struct {
+	cache  map[string]secretCacheItem
+	values map[string]struct{}
+	mux    sync.RWMutex
+}
+
+type secretCacheItem struct {
+	expire time.Time
+	value  string
+}
+
+func newSecretStore() *secretStore {
+	return &secretStore{
+		cache:  map[string]secretCacheItem{},
+		values: map[string]struct{}{},
+	}
+}
+
+func (s *secretStore) get(key string) (string, bool) {
+	if s == nil {
+		return "", false
+	}
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	item, ok := s.cache[key]
+	if !ok || time.Now().After(item.expire) {
+		return "", false
+	}
+	return item.value, true
+}
+
+func (s *secretStore) set(key string, value string, ttl time.Duration) {
+	if s == nil {
+		return
+	}
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if value != "" {
+		s.values[value] = struct{}{}
+	}
+	if ttl > 0 {
+		s.cache[key] = secretCacheItem{value: value, expire: time.Now().Add(ttl)}
+	}
+}
+
+func (s *secretStore) isSecret(value string) bool {
+	if s == nil || value == "" {
+		return false
+	}
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	_, ok := s.values[value]
+	return ok
+}
+
+func (s *secretStore) redactText(text string, mask string) string {
+	if s == nil {
+		return text
+	}
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	for v := range s.values {
+		if utf8.RuneCountInString(v) >= minSecretRedactLen {
+			text = strings.ReplaceAll(text, v, mask)
+		}
+	}
+	return text
+}
+
+// redactTree 隐藏通用数据结构中，值为敏感信息的字符串
+func (s *secretStore) redactTree(data any, mask string) any {
+	switch v := data.(type) {
+	case map[string]any:
+		for k, item := range v {
+			v[k] = s.redactTree(item, mask)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = s.redactTree(item, mask)
+		}
+	case string:
+		if s.isSecret(v) {
+			return mask
+		}
+	}
+	return data
+}
@@ -0,0 +1,186 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/fsgo/fst"
+)
+
+func TestConfigure_secret(t *testing.T) {
+	t.Setenv("FSCONF_TEST_DB_PASS", "env-pass-123")
+	secretFile := filepath.Join(t.TempDir(), "db")
+	fst.NoError(t, os.WriteFile(secretFile, []byte("file-pass-456\n"), 0600))
+
+	var calls atomic.Int32
+	vault := NewSecretProvider("vault", func(_ context.Context, key string) (string, error) {
+		calls.Add(1)
+		if key == "db/token" {
+			return "vault-token-789", nil
+		}
+		return "", ErrSecretNotFound
+	})
+
+	conf := NewDefault()
+	fst.NoError(t, conf.RegisterSecretProvider(vault))
+	fst.Error(t, conf.RegisterSecretProvider(vault))
+	fst.Error(t, conf.RegisterSecretProvider(NewSecretProvider("a.b", nil)))
+
+	type config struct {
+		Env   string
+		File  string
+		Vault string
+	}
+
+	content := `{"Env":"{secret.env:FSCONF_TEST_DB_PASS}","File":"{secret.file:` + filepath.ToSlash(secretFile) + `}","Vault":"{secret.vault:db/token}"}`
+
+	t.Run("resolve", func(t *testing.T) {
+		var cfg config
+		fst.NoError(t, conf.ParseBytes(".json", []byte(content), &cfg))
+		fst.Equal(t, config{Env: "env-pass-123", File: "file-pass-456", Vault: "vault-token-789"}, cfg)
+	})
+
+	t.Run("cache", func(t *testing.T) {
+		calls.Store(0)
+		var cfg config
+		fst.NoError(t, conf.Clone().ParseBytes(".json", []byte(content), &cfg))
+		fst.Equal(t, int32(0), calls.Load())
+
+		c1 := conf.Clone()
+		c1.SetSecretCacheTTL(-1)
+		fst.NoError(t, c1.ParseBytes(".json", []byte(content), &cfg))
+		fst.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		var cfg config
+		err := conf.ParseBytes(".json", []byte(`{"Vault":"{secret.vault:not_found}"}`), &cfg)
+		fst.Error(t, err)
+		fst.True(t, errors.Is(err, ErrSecretNotFound))
+		fst.Contains(t, err.Error(), "secret vault:not_found")
+
+		err = conf.ParseBytes(".json", []byte(`{"Vault":"{secret.env:FSCONF_TEST_NOT_FOUND}"}`), &cfg)
+		fst.Contains(t, err.Error(), "secret env:FSCONF_TEST_NOT_FOUND")
+
+		err = conf.ParseBytes(".json", []byte(`{"Vault":"{secret.kms:abc}"}`), &cfg)
+		fst.Contains(t, err.Error(), `provider "kms" not registered`)
+	})
+
+	t.Run("redact error", func(t *testing.T) {
+		type badConfig struct {
+			Env int
+		}
+		var cfg badConfig
+		err := conf.ParseBytes(".json", []byte(`{"Env":"{secret.env:FSCONF_TEST_DB_PASS}",}`), &cfg)
+		fst.Error(t, err)
+		var pe *ParseError
+		fst.True(t, errors.As(err, &pe))
+		fst.NotContains(t, err.Error(), "env-pass-123")
+
+		err = conf.ParseBytes(".json", []byte(`{"Env":{secret.env:FSCONF_TEST_DB_PASS}}`), &cfg)
+		fst.Error(t, err)
+		fst.NotContains(t, err.Error(), "env-pass-123")
+	})
+
+	t.Run("dump", func(t *testing.T) {
+		var cfg config
+		fst.NoError(t, conf.ParseBytes(".json", []byte(content), &cfg))
+		bf, err := conf.Dump(cfg)
+		fst.NoError(t, err)
+		fst.NotContains(t, string(bf), "pass-")
+		fst.NotContains(t, string(bf), "vault-token")
+	})
+}
+
+func TestSecretStore(t *testing.T) {
+	s := newSecretStore()
+	s.set("a", "abc", time.Millisecond)
+	v, ok := s.get("a")
+	fst.True(t, ok)
+	fst.Equal(t, "abc", v)
+	time.Sleep(2 * time.Millisecond)
+	_, ok = s.get("a")
+	fst.False(t, ok)
+
+	// 过短的值不在文本中替换
+	fst.Equal(t, "abc abcd", s.redactText("abc abcd", "***"))
+	s.set("b", "abcd", -1)
+	fst.Equal(t, "abc ***", s.redactText("abc abcd", "***"))
+	fst.True(t, s.isSecret("abc"))
+}
+
+func TestConfigure_secret_conf(t *testing.T) {
+	t.Run("file with fs", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"conf/app.json": {Data: []byte(`{"Pass":"{secret.file:/secrets/db}"}`)},
+			"secrets/db":    {Data: []byte("fs-pass-123\n")},
+		}
+		var cfg struct{ Pass string }
+		fst.NoError(t, NewDefault().WithFS(fsys).Parse("conf/app.json", &cfg))
+		fst.Equal(t, "fs-pass-123", cfg.Pass)
+	})
+
+	t.Run("env with env files", func(t *testing.T) {
+		dir := t.TempDir()
+		envFile := filepath.Join(dir, ".env")
+		fst.NoError(t, os.WriteFile(envFile, []byte("FSCONF_TEST_SECRET_ENV_FILE=dotenv-pass-123\n"), 0600))
+		c := NewDefault()
+		c.SetEnvFiles(envFile)
+		var cfg struct{ Pass string }
+		fst.NoError(t, c.ParseBytes(".json", []byte(`{"Pass":"{secret.env:FSCONF_TEST_SECRET_ENV_FILE}"}`), &cfg))
+		fst.Equal(t, "dotenv-pass-123", cfg.Pass)
+	})
+}
+
+func TestConfigure_secret_escape(t *testing.T) {
+	const pass = "a\"b\\c\nd\te"
+	conf := NewDefault()
+	fst.NoError(t, conf.RegisterSecretProvider(NewSecretProvider("raw", func(_ context.Context, key string) (string, error) {
+		return pass, nil
+	})))
+
+	t.Run("json string", func(t *testing.T) {
+		var cfg struct{ Pass, DSN string }
+		content := `{"Pass":"{secret.raw:p}", "DSN":"user:{secret.raw:p}@tcp"}`
+		fst.NoError(t, conf.ParseBytes(".json", []byte(content), &cfg))
+		fst.Equal(t, pass, cfg.Pass)
+		fst.Equal(t, "user:"+pass+"@tcp", cfg.DSN)
+	})
+
+	t.Run("not in string", func(t *testing.T) {
+		var cfg struct{ Pass string }
+		// 不在双引号字符串中时，原样替换
+		content := `{"Pass":{secret.raw:p}}`
+		fst.Error(t, conf.ParseBytes(".json", []byte(content), &cfg))
+	})
+}
+
+func Test_inDoubleQuote(t *testing.T) {
+	tests := []struct {
+		content string
+		want    bool
+	}{
+		{content: `"key": "X`, want: true},
+		{content: `"key": X`, want: false},
+		{content: `"k\"ey": "a\"b X`, want: true},
+		{content: `key = 'it"s' X`, want: false},
+		{content: "\"a\nkey = X", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.content, func(t *testing.T) {
+			got := inDoubleQuote([]byte(tt.content), strings.Index(tt.content, "X"))
+			fst.Equal(t, tt.want, got)
+		})
+	}
+}
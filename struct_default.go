@@ -0,0 +1,136 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/fsgo/fsconf/internal/tree"
+)
+
+// DefaultTag 用于设置字段默认值的 struct tag 名称
+//
+// 在配置解析完成后，Validator 校验之前，若字段的值是零值，则会使用 tag 的值作为默认值，如：
+//
+//	type Config struct {
+//		Port    int           `default:"8080"`
+//		Timeout time.Duration `default:"3s"`
+//		Hosts   []string      `default:"a,b"` // slice 使用逗号分隔
+//	}
+//
+// 会递归处理嵌套的 struct、非 nil 的 struct 指针，以及元素为 struct 的 slice、map。
+const DefaultTag = "default"
+
+// applyDefaults 设置 default tag 的值，rec 不为 nil 时，会记录设置的字段
+func applyDefaults(obj any, rec *traceRecorder) error {
+	rv := reflect.ValueOf(obj)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	d := &defaultsApplier{rec: rec}
+	return d.applyValue("", "", rv)
+}
+
+type defaultsApplier struct {
+	rec *traceRecorder
+}
+
+// applyValue path 是使用字段名的路径，用于错误信息，key 是使用配置中的名称的路径，用于 trace
+func (d *defaultsApplier) applyValue(path string, key string, rv reflect.Value) error {
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return nil
+		}
+		return d.applyValue(path, key, rv.Elem())
+	case reflect.Struct:
+		return d.applyStruct(path, key, rv)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if err := d.applyValue(fmt.Sprintf("%s[%d]", path, i), fmt.Sprintf("%s[%d]", key, i), rv.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if !hasStructElem(rv.Type().Elem()) {
+			return nil
+		}
+		iter := rv.MapRange()
+		for iter.Next() {
+			itemPath := fmt.Sprintf("%s[%v]", path, iter.Key().Interface())
+			itemKey := joinKeyPath(key, fmt.Sprint(iter.Key().Interface()))
+			ev := iter.Value()
+			if ev.Kind() == reflect.Ptr {
+				if err := d.applyValue(itemPath, itemKey, ev); err != nil {
+					return err
+				}
+				continue
+			}
+			// map 的值是不可寻址的，需要复制后再写回
+			cp := reflect.New(ev.Type()).Elem()
+			cp.Set(ev)
+			if err := d.applyValue(itemPath, itemKey, cp); err != nil {
+				return err
+			}
+			rv.SetMapIndex(iter.Key(), cp)
+		}
+	}
+	return nil
+}
+
+func hasStructElem(rt reflect.Type) bool {
+	for rt.Kind() == reflect.Ptr || rt.Kind() == reflect.Slice || rt.Kind() == reflect.Array || rt.Kind() == reflect.Map {
+		rt = rt.Elem()
+	}
+	return rt.Kind() == reflect.Struct
+}
+
+func (d *defaultsApplier) applyStruct(path string, key string, rv reflect.Value) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		if !sf.IsExported() && !sf.Anonymous {
+			continue
+		}
+		fv := rv.Field(i)
+		fieldPath := sf.Name
+		if path != "" {
+			fieldPath = path + "." + sf.Name
+		}
+		fieldKey := joinKeyPath(key, tree.KeyName(sf))
+		if sf.Anonymous {
+			fieldPath = path
+			fieldKey = key
+		}
+		if dv, ok := sf.Tag.Lookup(DefaultTag); ok && fv.IsZero() && fv.CanSet() {
+			if err := setFieldByString(fv, dv); err != nil {
+				return fmt.Errorf("set default value %q for %q: %w", dv, fieldPath, err)
+			}
+			d.rec.addOverride(&traceOverride{key: fieldKey, source: SourceDefault, field: sf, value: traceValue(fv)})
+		}
+		if err := d.applyValue(fieldPath, fieldKey, fv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setFieldByString 将字符串转换为字段的类型后赋值，slice 类型使用逗号分隔
+func setFieldByString(fv reflect.Value, value string) error {
+	var data any = value
+	if fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() != reflect.Uint8 {
+		var items []any
+		for _, item := range strings.Split(value, ",") {
+			items = append(items, strings.TrimSpace(item))
+		}
+		data = items
+	}
+	return tree.Decode(data, fv.Addr().Interface())
+}
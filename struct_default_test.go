@@ -0,0 +1,83 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fsgo/fst"
+)
+
+type testDefaultDB struct {
+	Host    string        `default:"127.0.0.1"`
+	Port    int           `default:"3306"`
+	Timeout time.Duration `default:"3s"`
+}
+
+type testDefaultConfig struct {
+	Name    string   `default:"demo"`
+	Port    uint16   `default:"8080"`
+	Enable  bool     `default:"true"`
+	Rate    float64  `default:"0.5"`
+	Tags    []string `default:"a, b"`
+	DB      testDefaultDB
+	Backup  *testDefaultDB
+	Nil     *testDefaultDB
+	Slaves  []testDefaultDB
+	Groups  map[string]testDefaultDB
+	Groups2 map[string]*testDefaultDB
+}
+
+func (tc *testDefaultConfig) AutoCheck() error {
+	if tc.DB.Port == 0 {
+		return errors.New("DB.Port is zero")
+	}
+	return nil
+}
+
+func TestApplyDefaults(t *testing.T) {
+	content := `{
+"Port": 80,
+"Backup":{"Host":"backup"},
+"Slaves":[{"Port":1}],
+"Groups":{"g1":{"Host":"g1"}},
+"Groups2":{"g2":{"Timeout":1000000000}}
+}`
+	t.Run("json", func(t *testing.T) {
+		var cfg testDefaultConfig
+		fst.NoError(t, ParseBytes(".json", []byte(content), &cfg))
+		def := testDefaultDB{Host: "127.0.0.1", Port: 3306, Timeout: 3 * time.Second}
+		want := testDefaultConfig{
+			Name:    "demo",
+			Port:    80,
+			Enable:  true,
+			Rate:    0.5,
+			Tags:    []string{"a", "b"},
+			DB:      def,
+			Backup:  &testDefaultDB{Host: "backup", Port: 3306, Timeout: 3 * time.Second},
+			Slaves:  []testDefaultDB{{Host: "127.0.0.1", Port: 1, Timeout: 3 * time.Second}},
+			Groups:  map[string]testDefaultDB{"g1": {Host: "g1", Port: 3306, Timeout: 3 * time.Second}},
+			Groups2: map[string]*testDefaultDB{"g2": {Host: "127.0.0.1", Port: 3306, Timeout: time.Second}},
+		}
+		fst.Equal(t, want, cfg)
+	})
+
+	t.Run("xml", func(t *testing.T) {
+		var cfg testDefaultDB
+		fst.NoError(t, ParseBytes(".xml", []byte(`<DB><Port>1</Port></DB>`), &cfg))
+		fst.Equal(t, testDefaultDB{Host: "127.0.0.1", Port: 1, Timeout: 3 * time.Second}, cfg)
+	})
+
+	t.Run("invalid default", func(t *testing.T) {
+		var cfg struct {
+			Port int `default:"abc"`
+		}
+		err := ParseBytes(".json", []byte(`{}`), &cfg)
+		fst.Error(t, err)
+		fst.Contains(t, err.Error(), `"Port"`)
+	})
+}
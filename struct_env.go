@@ -0,0 +1,201 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"encoding"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/fsgo/fsconf/internal/tree"
+)
+
+// EnvTag 用于绑定环境变量的 struct tag 名称
+const EnvTag = "env"
+
+// EnvOverride 使用环境变量覆盖配置字段的规则
+//
+//	type Config struct {
+//		Port int `env:"PORT"`
+//		DB   struct {
+//			Host string `env:"DB_HOST"`
+//			Port int
+//		}
+//	}
+//
+// 当 Prefix="APP" 时：
+// Port 绑定 APP_PORT，DB.Host 绑定 APP_DB_HOST，DB.Port 没有 tag，不会绑定；
+// 若同时 AutoName=true，则 DB.Port 会绑定 APP_DB_PORT。
+type EnvOverride struct {
+	// Prefix 所有环境变量名的前缀，可选，如 APP，会使用 "_" 和后面的名称连接
+	Prefix string
+
+	// AutoName 是否自动生成环境变量名
+	//
+	// 为 false 时，只处理有 env tag 的字段，环境变量名为 {Prefix}_{tag}；
+	// 为 true 时，所有字段都会处理，环境变量名为 {Prefix}_{父字段}_{字段}，
+	// 每段的名称优先使用 env tag，否则使用转换为大写下划线格式的字段名，如 MaxConns -> MAX_CONNS
+	AutoName bool
+
+	// Lookup 查找环境变量的方法，可选，默认为 os.LookupEnv
+	Lookup func(key string) (string, bool)
+}
+
+// SetEnvOverride 设置在配置解析完成后（在设置默认值之后、Validator 校验之前），
+// 使用环境变量覆盖配置字段的规则，传入 nil 则关闭此功能。
+//
+// 只有当环境变量存在并且不为空时，才会覆盖字段的值，slice 类型使用逗号分隔。
+func (c *Configure) SetEnvOverride(eo *EnvOverride) {
+	c.envOverride = eo
+}
+
+func (eo *EnvOverride) lookup(key string) (string, bool) {
+	if eo.Lookup != nil {
+		return eo.Lookup(key)
+	}
+	return os.LookupEnv(key)
+}
+
+// apply 使用环境变量覆盖 obj 的字段，rec 不为 nil 时，会记录覆盖的字段
+func (eo *EnvOverride) apply(obj any, r *Redactor, rec *traceRecorder) error {
+	rv := reflect.ValueOf(obj)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+	ea := &envApplier{eo: eo, r: r, rec: rec}
+	return ea.applyStruct("", "", eo.Prefix, rv)
+}
+
+type envApplier struct {
+	eo  *EnvOverride
+	r   *Redactor
+	rec *traceRecorder
+}
+
+// applyStruct path 是使用字段名的路径，用于错误信息，key 是使用配置中的名称的路径，用于 trace
+func (ea *envApplier) applyStruct(path string, key string, prefix string, rv reflect.Value) error {
+	eo, r := ea.eo, ea.r
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		if !sf.IsExported() && !sf.Anonymous {
+			continue
+		}
+		tag, hasTag := sf.Tag.Lookup(EnvTag)
+		if tag == "-" {
+			continue
+		}
+		fv := rv.Field(i)
+		fieldPath := sf.Name
+		if path != "" {
+			fieldPath = path + "." + sf.Name
+		}
+		fieldKey := joinKeyPath(key, tree.KeyName(sf))
+
+		if sub, ok := structValue(fv); ok && !hasTextUnmarshaler(fv) {
+			subPrefix := prefix
+			if !sf.Anonymous && eo.AutoName {
+				name := tag
+				if !hasTag {
+					name = envName(sf.Name)
+				}
+				subPrefix = joinEnvName(prefix, name)
+			}
+			if sf.Anonymous {
+				fieldPath = path
+				fieldKey = key
+			}
+			if err := ea.applyStruct(fieldPath, fieldKey, subPrefix, sub); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !fv.CanSet() {
+			continue
+		}
+		var name string
+		switch {
+		case eo.AutoName && hasTag:
+			name = joinEnvName(prefix, tag)
+		case eo.AutoName:
+			name = joinEnvName(prefix, envName(sf.Name))
+		case hasTag:
+			name = joinEnvName(eo.Prefix, tag)
+		default:
+			continue
+		}
+		value, ok := eo.lookup(name)
+		if !ok || value == "" {
+			continue
+		}
+		if err := setFieldByString(fv, value); err != nil {
+			if r.isSecretField(sf) || r.IsSecretKey(name) {
+				// 错误信息中可能包含敏感的值
+				return fmt.Errorf("invalid env %s for field %q: invalid value for %s", name, fieldPath, fv.Type())
+			}
+			return fmt.Errorf("invalid env %s for field %q: %w", name, fieldPath, err)
+		}
+		ea.rec.addOverride(&traceOverride{key: fieldKey, source: SourceEnv, env: name, field: sf, value: traceValue(fv)})
+	}
+	return nil
+}
+
+// structValue 若 fv 是 struct 或者非 nil 的 struct 指针，返回该 struct
+func structValue(fv reflect.Value) (reflect.Value, bool) {
+	if fv.Kind() == reflect.Ptr {
+		if fv.IsNil() {
+			return reflect.Value{}, false
+		}
+		fv = fv.Elem()
+	}
+	return fv, fv.Kind() == reflect.Struct
+}
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// hasTextUnmarshaler 如 time.Time 之类的 struct，应作为一个整体赋值
+func hasTextUnmarshaler(fv reflect.Value) bool {
+	if fv.Kind() != reflect.Ptr {
+		if !fv.CanAddr() {
+			return false
+		}
+		fv = fv.Addr()
+	}
+	return fv.Type().Implements(textUnmarshalerType)
+}
+
+func joinEnvName(prefix string, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "_" + name
+}
+
+// envName 将字段名转换为大写下划线格式，如 MaxConns -> MAX_CONNS，HTTPServer -> HTTP_SERVER
+func envName(name string) string {
+	rs := []rune(name)
+	var b strings.Builder
+	for i, r := range rs {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := rs[i-1]
+			nextLower := i+1 < len(rs) && unicode.IsLower(rs[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
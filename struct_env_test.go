@@ -0,0 +1,109 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fsgo/fst"
+)
+
+type testEnvDB struct {
+	Host     string `env:"DB_HOST"`
+	Port     int
+	MaxConns int
+	Timeout  time.Duration `env:"DB_TIMEOUT"`
+}
+
+type testEnvConfig struct {
+	Name    string `env:"NAME"`
+	Port    int    `env:"PORT" default:"80"`
+	Ignore  string `env:"-"`
+	Hosts   []string
+	DB      testEnvDB
+	Backup  *testEnvDB `env:"BAK"`
+	Created time.Time
+}
+
+func TestEnvOverride(t *testing.T) {
+	content := []byte(`{"Name":"file","Port":0,"DB":{"Host":"file","Port":3306},"Backup":{"Port":1}}`)
+
+	t.Run("tag only", func(t *testing.T) {
+		t.Setenv("APP_NAME", "env")
+		t.Setenv("APP_DB_HOST", "env-host")
+		t.Setenv("APP_DB_TIMEOUT", "3s")
+		t.Setenv("APP_PORT", "")
+		t.Setenv("APP_DB_PORT", "1")
+		c := NewDefault()
+		c.SetEnvOverride(&EnvOverride{Prefix: "APP"})
+		var cfg testEnvConfig
+		fst.NoError(t, c.ParseBytes(".json", content, &cfg))
+		want := testEnvConfig{
+			Name:   "env",
+			Port:   80,
+			DB:     testEnvDB{Host: "env-host", Port: 3306, Timeout: 3 * time.Second},
+			Backup: &testEnvDB{Host: "env-host", Port: 1, Timeout: 3 * time.Second},
+		}
+		fst.Equal(t, want, cfg)
+	})
+
+	t.Run("auto name", func(t *testing.T) {
+		envs := map[string]string{
+			"APP_PORT":           "8080",
+			"APP_IGNORE":         "ignore",
+			"APP_HOSTS":          "a,b",
+			"APP_DB_PORT":        "3307",
+			"APP_DB_MAX_CONNS":   "10",
+			"APP_DB_DB_HOST":     "auto-host",
+			"APP_BAK_PORT":       "2",
+			"APP_CREATED":        "2026-10-18T00:00:00Z",
+			"APP_DB_DB_TIMEOUT":  "1s",
+			"APP_BACKUP_PORT":    "3",
+			"APP_DB_TIMEOUT":     "2s",
+			"APP_NAME":           "auto",
+			"APP_BAK_MAX_CONNS":  "5",
+			"APP_BAK_DB_TIMEOUT": "4s",
+		}
+		c := NewDefault()
+		c.SetEnvOverride(&EnvOverride{
+			Prefix:   "APP",
+			AutoName: true,
+			Lookup: func(key string) (string, bool) {
+				v, ok := envs[key]
+				return v, ok
+			},
+		})
+		var cfg testEnvConfig
+		fst.NoError(t, c.ParseBytes(".json", content, &cfg))
+		want := testEnvConfig{
+			Name:    "auto",
+			Port:    8080,
+			Hosts:   []string{"a", "b"},
+			DB:      testEnvDB{Host: "auto-host", Port: 3307, MaxConns: 10, Timeout: time.Second},
+			Backup:  &testEnvDB{Port: 2, MaxConns: 5, Timeout: 4 * time.Second},
+			Created: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+		}
+		fst.Equal(t, want, cfg)
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		t.Setenv("APP_DB_TIMEOUT", "abc")
+		c := NewDefault()
+		c.SetEnvOverride(&EnvOverride{Prefix: "APP"})
+		var cfg testEnvConfig
+		err := c.ParseBytes(".json", content, &cfg)
+		fst.Error(t, err)
+		fst.Contains(t, err.Error(), "APP_DB_TIMEOUT")
+		fst.Contains(t, err.Error(), `"DB.Timeout"`)
+	})
+}
+
+func Test_envName(t *testing.T) {
+	fst.Equal(t, "MAX_CONNS", envName("MaxConns"))
+	fst.Equal(t, "HTTP_SERVER", envName("HTTPServer"))
+	fst.Equal(t, "DB", envName("DB"))
+	fst.Equal(t, "V2_NAME", envName("V2Name"))
+}
@@ -0,0 +1,460 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/fsgo/fsconf/internal/tree"
+)
+
+// Trace 配置中每个最终生效的值的来源
+type Trace struct {
+	// Values 按照 Key 排序
+	Values []*ValueTrace
+}
+
+// Get 查找 key 对应的值的来源，找不到时返回 nil
+func (t *Trace) Get(key string) *ValueTrace {
+	for _, v := range t.Values {
+		if v.Key == key {
+			return v
+		}
+	}
+	return nil
+}
+
+// String 返回可读的格式，如：
+//
+//	DB.Port = 3306
+//	    from conf/app.toml:3
+//	    osenv: port = {osenv.DB_PORT|3306} -> port = 3306
+func (t *Trace) String() string {
+	var b strings.Builder
+	for _, v := range t.Values {
+		b.WriteString(v.String())
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// ValueSource 值的来源类型
+type ValueSource string
+
+const (
+	// SourceFile 值来自配置文件
+	SourceFile ValueSource = "file"
+
+	// SourceDefault 值来自字段的 default tag
+	SourceDefault ValueSource = "default"
+
+	// SourceEnv 值来自 SetEnvOverride 设置的环境变量
+	SourceEnv ValueSource = "env"
+)
+
+// ValueTrace 一个值的来源
+type ValueTrace struct {
+	// Key 值的路径，如 DB.Port、Hosts[0]，和配置文件中的 key 一致，
+	// 对于不在配置文件中的值，使用字段在配置中的名称（json 等 tag 或者字段名）
+	Key string
+
+	// Value 最终生效的值，敏感的值会被隐藏
+	Value any
+
+	// Source 值的来源类型，只有为 SourceFile 时，File、Line、Rewrites 才有值
+	Source ValueSource
+
+	// Env 当 Source 为 SourceEnv 时，覆盖该值的环境变量名称，如 APP_DB_PORT
+	Env string
+
+	// File 定义该值的文件，当直接解析内容（如 ParseBytes）时，为空字符串
+	File string
+
+	// Line 定义该值的行号，从 1 开始，为 0 时表示未能找到
+	Line int
+
+	// Rewrites 经过的 Hook 对该行内容的改写，按照执行顺序排列
+	Rewrites []*Rewrite
+}
+
+func (v *ValueTrace) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s = %v\n", v.Key, v.Value)
+	switch v.Source {
+	case SourceEnv:
+		fmt.Fprintf(&b, "    from env %s", v.Env)
+		return b.String()
+	case SourceDefault:
+		b.WriteString("    from default tag")
+		return b.String()
+	}
+	file := v.File
+	if file == "" {
+		file = "(bytes)"
+	}
+	if v.Line > 0 {
+		fmt.Fprintf(&b, "    from %s:%d", file, v.Line)
+	} else {
+		fmt.Fprintf(&b, "    from %s", file)
+	}
+	for _, r := range v.Rewrites {
+		if r.Before == "" {
+			fmt.Fprintf(&b, "\n    %s: generated %s", r.Hook, r.After)
+			continue
+		}
+		fmt.Fprintf(&b, "\n    %s: %s -> %s", r.Hook, r.Before, r.After)
+	}
+	return b.String()
+}
+
+// Rewrite Hook 对一行内容的改写
+type Rewrite struct {
+	// Hook 名称，如 osenv、fsenv、template
+	Hook string
+
+	// Before 改写前的内容，为空时表示该行是由 Hook 生成的，如 template 的 include
+	Before string
+
+	// After 改写后的内容
+	After string
+}
+
+// ParseWithTrace 解析配置，并返回每个值的来源：定义它的文件和行号，以及哪些 Hook 改写了它。
+//
+// 对于 ParseLayers、SetProfileOverlay 等涉及多个文件的情况，返回的是最终生效的那个文件中的位置。
+// 由于是从文本中按照 key 名称查找，所以定位的行号不保证完全准确。
+//
+// 解析完成后由 default tag 或者 SetEnvOverride 的环境变量设置的值，Source 分别为 SourceDefault 和 SourceEnv。
+// 在 ParseWithTrace 返回之后才修改配置的方式（如 BindFlags 绑定的命令行参数）不包含在内。
+func (c *Configure) ParseWithTrace(confName string, obj any) (*Trace, error) {
+	rec := &traceRecorder{}
+	c1 := c.WithContext(withTraceRecorder(c.context(), rec))
+	if err := c1.Parse(confName, obj); err != nil {
+		return nil, err
+	}
+	return c.buildTrace(rec, reflect.TypeOf(obj))
+}
+
+// Explain 解析配置，并返回每个值的来源，和 ParseWithTrace 相同，但是不需要传入 obj
+func (c *Configure) Explain(confName string) (*Trace, error) {
+	var obj any
+	return c.ParseWithTrace(confName, &obj)
+}
+
+func (c *Configure) buildTrace(rec *traceRecorder, objType reflect.Type) (*Trace, error) {
+	secrets := map[string]bool{}
+	secretPaths(objType, "", secrets, 0)
+	r := c.redactor()
+
+	result := map[string]*ValueTrace{}
+	for _, ft := range rec.list() {
+		data, err := c.decodeTraceData(ft)
+		if err != nil {
+			return nil, err
+		}
+		leaves := map[string]*traceLeaf{}
+		flattenTree(data, nil, leaves)
+
+		// 重新解析一份用于判断哪些值是敏感的，redactTree 会直接修改数据
+		redacted, _ := c.decodeTraceData(ft)
+		redactedLeaves := map[string]*traceLeaf{}
+		flattenTree(r.redactTree("", redacted, secrets), nil, redactedLeaves)
+
+		renderedLines := splitLines(ft.rendered)
+		for key, lf := range leaves {
+			vt := &ValueTrace{
+				Key:    key,
+				Value:  lf.value,
+				Source: SourceFile,
+				File:   ft.path,
+			}
+			if line := findKeyLine(renderedLines, lf.names); line > 0 {
+				vt.File, vt.Line, vt.Rewrites = ft.source(line, lf.names[len(lf.names)-1], c.readFile)
+			}
+			str, isStr := lf.value.(string)
+			if rl, ok := redactedLeaves[key]; !ok || rl.value != lf.value || (isStr && c.secrets.isSecret(str)) {
+				vt.Value = r.mask()
+			}
+			for _, rw := range vt.Rewrites {
+				rw.Before = c.redactText(rw.Before)
+				rw.After = c.redactText(rw.After)
+			}
+			result[key] = vt
+		}
+	}
+	for _, o := range rec.overrideList() {
+		c.applyTraceOverride(result, o, r)
+	}
+	t := &Trace{}
+	for _, v := range result {
+		t.Values = append(t.Values, v)
+	}
+	sort.Slice(t.Values, func(i, j int) bool {
+		return t.Values[i].Key < t.Values[j].Key
+	})
+	return t, nil
+}
+
+// applyTraceOverride 使用解析完成后对字段的修改，替换 result 中该字段（以及其子节点）的来源
+func (c *Configure) applyTraceOverride(result map[string]*ValueTrace, o *traceOverride, r *Redactor) {
+	lower := strings.ToLower(o.key)
+	key := o.key
+	for k := range result {
+		lk := strings.ToLower(k)
+		switch {
+		case lk == lower:
+			// 使用配置文件中的 key，如 port 而不是字段名 Port
+			key = k
+			delete(result, k)
+		case strings.HasPrefix(lk, lower+"."), strings.HasPrefix(lk, lower+"["):
+			delete(result, k)
+		}
+	}
+	vt := &ValueTrace{
+		Key:    key,
+		Value:  o.value,
+		Source: o.source,
+		Env:    o.env,
+	}
+	str, isStr := o.value.(string)
+	if r.isSecretField(o.field) || r.IsSecretKey(key) || r.IsSecretKey(o.env) || (isStr && c.secrets.isSecret(str)) {
+		vt.Value = r.mask()
+	}
+	result[key] = vt
+}
+
+func (c *Configure) decodeTraceData(ft *fileTrace) (any, error) {
+	var data any
+	if err := c.parsers[ft.fileExt](ft.rendered, &data); err != nil {
+		// 如 xml 格式，不能解析为通用的数据结构
+		return nil, fmt.Errorf("cannot trace %q: %w", ft.path, err)
+	}
+	return tree.Normalize(data), nil
+}
+
+// traceLeaf 配置中的一个叶子节点
+type traceLeaf struct {
+	value any
+	names []string // 路径的每一段，不包含数组下标
+}
+
+// flattenTree 展开所有的叶子节点，key 为完整的路径，如 DB.Port、Hosts[0]
+func flattenTree(data any, segs []string, leaves map[string]*traceLeaf) {
+	switch v := data.(type) {
+	case map[string]any:
+		for k, item := range v {
+			flattenTree(item, append(append([]string{}, segs...), k), leaves)
+		}
+	case []any:
+		for i, item := range v {
+			flattenTree(item, append(append([]string{}, segs...), "["+strconv.Itoa(i)+"]"), leaves)
+		}
+	default:
+		if len(segs) == 0 {
+			return
+		}
+		var b strings.Builder
+		var names []string
+		for i, s := range segs {
+			if strings.HasPrefix(s, "[") {
+				b.WriteString(s)
+				continue
+			}
+			if i > 0 {
+				b.WriteString(".")
+			}
+			b.WriteString(s)
+			names = append(names, s)
+		}
+		leaves[b.String()] = &traceLeaf{value: v, names: names}
+	}
+}
+
+// findKeyLine 在内容中依次查找路径的每一段，返回最后一段所在的行号，从 1 开始，找不到时返回 0
+func findKeyLine(lines []string, segs []string) int {
+	start := 0
+	found := -1
+	for _, seg := range segs {
+		found = -1
+		for i := start; i < len(lines); i++ {
+			if lineHasKey(lines[i], seg) {
+				found = i
+				break
+			}
+		}
+		if found < 0 {
+			return 0
+		}
+		start = found
+	}
+	return found + 1
+}
+
+var keyRegCache sync.Map // map[string]*regexp.Regexp
+
+// lineHasKey 判断该行是否定义了 key，支持 key = v、"key": v、[key]、<key> 等格式
+func lineHasKey(line string, key string) bool {
+	v, ok := keyRegCache.Load(key)
+	if !ok {
+		k := regexp.QuoteMeta(key)
+		v = regexp.MustCompile(`(?:^|[\s"'{,\[.<])` + k + `(?:["']?\s*[:=]|["']?\s*\]|\.|>|$)`)
+		keyRegCache.Store(key, v)
+	}
+	return v.(*regexp.Regexp).MatchString(line)
+}
+
+// fileTrace 一个文件在 Hook 处理过程中的各个阶段
+type fileTrace struct {
+	path     string
+	fileExt  string
+	rendered []byte
+	stages   []*hookStage
+	includes []string
+}
+
+// source 将 rendered 中的第 line 行，逆向经过各个 Hook，找到其在原始文件中的位置
+func (ft *fileTrace) source(line int, key string, readFile func(string) ([]byte, error)) (file string, srcLine int, rewrites []*Rewrite) {
+	cur := line
+	for i := len(ft.stages) - 1; i >= 0; i-- {
+		st := ft.stages[i]
+		in, out := splitLines(st.input), splitLines(st.output)
+		if cur > len(out) {
+			return ft.path, 0, rewrites
+		}
+		if len(in) == len(out) {
+			if in[cur-1] != out[cur-1] {
+				rewrites = append([]*Rewrite{{Hook: st.name, Before: strings.TrimSpace(in[cur-1]), After: strings.TrimSpace(out[cur-1])}}, rewrites...)
+			}
+			continue
+		}
+		if idx := findLine(in, out, cur); idx > 0 {
+			cur = idx
+			continue
+		}
+		// 该行是由 Hook 生成的，如 template 的 include 或者表达式
+		if idx := findKeyLine(in, []string{key}); idx > 0 {
+			rewrites = append([]*Rewrite{{Hook: st.name, Before: strings.TrimSpace(in[idx-1]), After: strings.TrimSpace(out[cur-1])}}, rewrites...)
+			cur = idx
+			continue
+		}
+		rewrites = append([]*Rewrite{{Hook: st.name, After: strings.TrimSpace(out[cur-1])}}, rewrites...)
+		for _, fp := range ft.includes {
+			bf, err := readFile(fp)
+			if err != nil {
+				continue
+			}
+			if idx := findLine(splitLines(bf), out, cur); idx > 0 {
+				return fp, idx, rewrites
+			}
+		}
+		return ft.path, 0, rewrites
+	}
+	return ft.path, cur, rewrites
+}
+
+type traceRecorder struct {
+	files     []*fileTrace
+	overrides []*traceOverride
+	mux       sync.Mutex
+}
+
+// traceOverride 解析完成后对字段的修改，如 default tag、环境变量
+type traceOverride struct {
+	value  any
+	key    string // 使用配置中的名称的路径，如 DB.Port
+	source ValueSource
+	env    string // 环境变量名称
+	field  reflect.StructField
+}
+
+// addOverride 记录解析完成后对字段的修改，tr 为 nil 时不记录
+func (tr *traceRecorder) addOverride(o *traceOverride) {
+	if tr == nil {
+		return
+	}
+	tr.mux.Lock()
+	defer tr.mux.Unlock()
+	tr.overrides = append(tr.overrides, o)
+}
+
+func (tr *traceRecorder) overrideList() []*traceOverride {
+	tr.mux.Lock()
+	defer tr.mux.Unlock()
+	return append([]*traceOverride{}, tr.overrides...)
+}
+
+// traceValue 返回字段的值，指针类型会返回其指向的值
+func traceValue(fv reflect.Value) any {
+	for fv.Kind() == reflect.Ptr {
+		if fv.IsNil() {
+			return nil
+		}
+		fv = fv.Elem()
+	}
+	return fv.Interface()
+}
+
+func (tr *traceRecorder) add(ft *fileTrace) {
+	tr.mux.Lock()
+	defer tr.mux.Unlock()
+	tr.files = append(tr.files, ft)
+}
+
+func (tr *traceRecorder) list() []*fileTrace {
+	tr.mux.Lock()
+	defer tr.mux.Unlock()
+	return append([]*fileTrace{}, tr.files...)
+}
+
+type hookStage struct {
+	name   string
+	input  []byte
+	output []byte
+}
+
+type hookStages struct {
+	stages []*hookStage
+	mux    sync.Mutex
+}
+
+func (hs *hookStages) add(name string, input []byte, output []byte) {
+	hs.mux.Lock()
+	defer hs.mux.Unlock()
+	hs.stages = append(hs.stages, &hookStage{name: name, input: input, output: output})
+}
+
+func (hs *hookStages) list() []*hookStage {
+	hs.mux.Lock()
+	defer hs.mux.Unlock()
+	return append([]*hookStage{}, hs.stages...)
+}
+
+func withTraceRecorder(ctx context.Context, tr *traceRecorder) context.Context {
+	return context.WithValue(ctx, ctxKeyTraceRecorder, tr)
+}
+
+func traceRecorderFromContext(ctx context.Context) *traceRecorder {
+	tr, _ := ctx.Value(ctxKeyTraceRecorder).(*traceRecorder)
+	return tr
+}
+
+func withHookStages(ctx context.Context, hs *hookStages) context.Context {
+	return context.WithValue(ctx, ctxKeyHookStages, hs)
+}
+
+func hookStagesFromContext(ctx context.Context) *hookStages {
+	if ctx == nil {
+		return nil
+	}
+	hs, _ := ctx.Value(ctxKeyHookStages).(*hookStages)
+	return hs
+}
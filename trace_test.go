@@ -0,0 +1,116 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fsgo/fst"
+)
+
+func TestParseWithTrace(t *testing.T) {
+	t.Setenv("FSCONF_TRACE_PORT", "9090")
+	type config struct {
+		Name     string
+		Port     int
+		Password string
+		DB       struct {
+			Host string
+		}
+	}
+	var cfg config
+	tr, err := ParseWithTrace("testdata/conf/trace/app.json", &cfg)
+	fst.NoError(t, err)
+	fst.Equal(t, 9090, cfg.Port)
+
+	name := tr.Get("Name")
+	fst.NotNil(t, name)
+	fst.Equal(t, "testdata/conf/trace/app.json", relPath(t, name.File))
+	fst.Equal(t, 3, name.Line)
+	fst.Empty(t, name.Rewrites)
+
+	port := tr.Get("Port")
+	fst.NotNil(t, port)
+	fst.Equal(t, 4, port.Line)
+	fst.Len(t, port.Rewrites, 1)
+	fst.Equal(t, "osenv", port.Rewrites[0].Hook)
+	fst.Equal(t, `"Port": {osenv.FSCONF_TRACE_PORT|8080},`, port.Rewrites[0].Before)
+	fst.Equal(t, `"Port": 9090,`, port.Rewrites[0].After)
+
+	host := tr.Get("DB.Host")
+	fst.NotNil(t, host)
+	fst.Equal(t, "testdata/conf/trace/sub/db.json", relPath(t, host.File))
+	fst.Equal(t, 2, host.Line)
+	fst.Equal(t, "template", host.Rewrites[0].Hook)
+
+	pwd := tr.Get("Password")
+	fst.NotNil(t, pwd)
+	fst.Equal[any](t, "******", pwd.Value)
+	fst.NotContains(t, tr.String(), "abc")
+
+	fst.Nil(t, tr.Get("NotFound"))
+}
+
+func TestExplain(t *testing.T) {
+	tr, err := Explain("testdata/conf/abc.json")
+	fst.NoError(t, err)
+	fst.NotEmpty(t, tr.Values)
+	for _, v := range tr.Values {
+		fst.Greater(t, v.Line, 0)
+	}
+}
+
+func relPath(t *testing.T, fp string) string {
+	wd, err := filepath.Abs(".")
+	fst.NoError(t, err)
+	rel, err := filepath.Rel(wd, fp)
+	fst.NoError(t, err)
+	return filepath.ToSlash(rel)
+}
+
+func TestParseWithTrace_override(t *testing.T) {
+	t.Setenv("FSCONF_TRACE_APP_PORT", "9999")
+	t.Setenv("FSCONF_TRACE_APP_TOKEN", "env-token-value")
+	type config struct {
+		Name    string
+		Port    int    `json:"port" env:"PORT"`
+		Timeout string `default:"3s"`
+		Token   string `env:"TOKEN"`
+	}
+	dir := t.TempDir()
+	fp := filepath.Join(dir, "app.json")
+	fst.NoError(t, os.WriteFile(fp, []byte("{\n\"Name\":\"demo\",\n\"port\":1\n}"), 0644))
+
+	c := NewDefault()
+	c.SetEnvOverride(&EnvOverride{Prefix: "FSCONF_TRACE_APP"})
+	var cfg config
+	tr, err := c.ParseWithTrace(fp, &cfg)
+	fst.NoError(t, err)
+	fst.Equal(t, 9999, cfg.Port)
+
+	name := tr.Get("Name")
+	fst.Equal(t, SourceFile, name.Source)
+	fst.Equal(t, 2, name.Line)
+
+	port := tr.Get("port")
+	fst.NotNil(t, port)
+	fst.Equal(t, SourceEnv, port.Source)
+	fst.Equal(t, "FSCONF_TRACE_APP_PORT", port.Env)
+	fst.Equal[any](t, 9999, port.Value)
+	fst.Equal(t, "", port.File)
+	fst.Contains(t, port.String(), "from env FSCONF_TRACE_APP_PORT")
+
+	timeout := tr.Get("Timeout")
+	fst.NotNil(t, timeout)
+	fst.Equal(t, SourceDefault, timeout.Source)
+	fst.Equal[any](t, "3s", timeout.Value)
+
+	token := tr.Get("Token")
+	fst.NotNil(t, token)
+	fst.Equal[any](t, "******", token.Value)
+	fst.NotContains(t, tr.String(), "env-token-value")
+}
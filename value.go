@@ -0,0 +1,59 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"sync/atomic"
+)
+
+// Value 持有一份类型为 T 的配置，可以并发安全的读取和重新加载
+type Value[T any] struct {
+	conf     *Configure
+	confName string
+	value    atomic.Pointer[T]
+}
+
+// Load （全局）使用默认的 Configure 解析配置，并返回持有该配置的 Value
+func Load[T any](confName string) (*Value[T], error) {
+	return NewValue[T](Default(), confName)
+}
+
+// MustLoad 调用 Load，若返回 err!=nil 则 panic
+func MustLoad[T any](confName string) *Value[T] {
+	v, err := Load[T](confName)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// NewValue 使用指定的 Configure 解析配置，并返回持有该配置的 Value，
+// Configure 上注册的 parser、hook 等都会生效
+func NewValue[T any](c *Configure, confName string) (*Value[T], error) {
+	v := &Value[T]{
+		conf:     c,
+		confName: confName,
+	}
+	if err := v.Reload(); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Get 返回当前的配置，返回值是只读的快照，调用方不应修改其内容
+func (v *Value[T]) Get() *T {
+	return v.value.Load()
+}
+
+// Reload 重新解析配置，只有当解析、Validator 校验以及 AutoCheck 都成功后才会替换为新值，
+// 否则会继续保留上一次成功加载的值
+func (v *Value[T]) Reload() error {
+	obj := new(T)
+	if err := v.conf.Parse(v.confName, obj); err != nil {
+		return err
+	}
+	v.value.Store(obj)
+	return nil
+}
@@ -0,0 +1,56 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fsgo/fst"
+)
+
+type testValueConfig struct {
+	Port int
+}
+
+func (tc *testValueConfig) AutoCheck() error {
+	if tc.Port <= 0 {
+		return errors.New("invalid Port")
+	}
+	return nil
+}
+
+func TestValue(t *testing.T) {
+	fp := filepath.Join(t.TempDir(), "app.json")
+	fst.NoError(t, os.WriteFile(fp, []byte(`{"Port":80}`), 0644))
+
+	v, err := Load[testValueConfig](fp)
+	fst.NoError(t, err)
+	fst.Equal(t, &testValueConfig{Port: 80}, v.Get())
+	old := v.Get()
+
+	fst.NoError(t, os.WriteFile(fp, []byte(`{"Port":8080}`), 0644))
+	fst.NoError(t, v.Reload())
+	fst.Equal(t, &testValueConfig{Port: 8080}, v.Get())
+	fst.Equal(t, &testValueConfig{Port: 80}, old)
+
+	// AutoCheck 失败，保留上一次的值
+	fst.NoError(t, os.WriteFile(fp, []byte(`{"Port":-1}`), 0644))
+	fst.Error(t, v.Reload())
+	fst.Equal(t, &testValueConfig{Port: 8080}, v.Get())
+
+	// 解析失败，保留上一次的值
+	fst.NoError(t, os.WriteFile(fp, []byte(`{"Port":`), 0644))
+	fst.Error(t, v.Reload())
+	fst.Equal(t, &testValueConfig{Port: 8080}, v.Get())
+}
+
+func TestLoad_error(t *testing.T) {
+	v, err := Load[testValueConfig]("not_exists.json")
+	fst.Error(t, err)
+	fst.Nil(t, v)
+}
@@ -0,0 +1,155 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"time"
+)
+
+const (
+	// defaultWatchInterval Watch 默认检查文件是否有变化的时间间隔
+	defaultWatchInterval = time.Second
+
+	// defaultWatchDebounce Watch 默认的防抖时长
+	defaultWatchDebounce = 300 * time.Millisecond
+)
+
+// WatchOption Watch 的选项
+type WatchOption func(o *watchOptions)
+
+type watchOptions struct {
+	interval time.Duration
+	debounce time.Duration
+}
+
+// WatchInterval 设置 Watch 检查文件是否有变化的时间间隔，默认为 1s
+func WatchInterval(d time.Duration) WatchOption {
+	return func(o *watchOptions) {
+		if d > 0 {
+			o.interval = d
+		}
+	}
+}
+
+// WatchDebounce 设置 Watch 的防抖时长，发现文件变化后，需要文件保持此时长不再变化，才会重新解析配置，
+// 默认为 300ms
+func WatchDebounce(d time.Duration) WatchOption {
+	return func(o *watchOptions) {
+		if d >= 0 {
+			o.debounce = d
+		}
+	}
+}
+
+// OnChangeFunc 配置文件变化后的回调函数
+//
+//	obj: 新解析出的配置对象，和传入 Watch 的 obj 类型相同
+//	err: 重新解析失败时的错误，此时 obj 为 nil
+type OnChangeFunc func(obj any, err error)
+
+// Watch 解析配置，并监听配置文件（包括 template hook 中 include 的文件）的变化，
+// 当文件变化后，会使用完整的 Hook、DecoderFunc、Validator、AutoChecker 流程重新解析配置，
+// 并回调 onChange。
+//
+// 首次解析会直接将配置解析到 obj 上，若失败会返回 error，并且不会开始监听。
+// 后续每次变化都会创建一个和 obj 相同类型的新对象用于解析，不会修改 obj。
+// ctx 结束后，监听会停止，ctx 不可为 nil。
+func (c *Configure) Watch(ctx context.Context, confName string, obj any, onChange OnChangeFunc, opts ...WatchOption) error {
+	if ctx == nil {
+		return errors.New("ctx is nil")
+	}
+	if onChange == nil {
+		return errors.New("onChange is nil")
+	}
+	rt := reflect.TypeOf(obj)
+	if rt == nil || rt.Kind() != reflect.Ptr {
+		return fmt.Errorf("obj must be a non-nil pointer, got %T", obj)
+	}
+	ft, err := c.parseTracked(confName, obj)
+	if err != nil {
+		return err
+	}
+	w := &watcher{
+		conf:     c,
+		confName: confName,
+		objType:  rt.Elem(),
+		onChange: onChange,
+		tracker:  ft,
+		opts: watchOptions{
+			interval: defaultWatchInterval,
+			debounce: defaultWatchDebounce,
+		},
+	}
+	for _, opt := range opts {
+		opt(&w.opts)
+	}
+	// 需要在返回前获取文件状态，否则返回后立即发生的变化可能会被遗漏
+	go w.run(ctx, ft.snapshot(c))
+	return nil
+}
+
+// parseTracked 解析配置，并记录读取的文件，每次都会重新查找配置文件，
+// 以便使用 SetSearchPaths 时，能够发现其他目录中新增的文件
+func (c *Configure) parseTracked(confName string, obj any) (*fileTracker, error) {
+	ft := &fileTracker{}
+	c1 := c.WithContext(withFileTracker(c.context(), ft))
+	err := c1.Parse(confName, obj)
+	return ft, err
+}
+
+type watcher struct {
+	conf     *Configure
+	objType  reflect.Type
+	onChange OnChangeFunc
+	tracker  *fileTracker
+	confName string
+	opts     watchOptions
+}
+
+func (w *watcher) run(ctx context.Context, last map[string]string) {
+	ticker := time.NewTicker(w.opts.interval)
+	defer ticker.Stop()
+
+	var pending bool
+	var changedAt time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		cur := w.tracker.snapshot(w.conf)
+		if !maps.Equal(cur, last) {
+			last = cur
+			pending = true
+			changedAt = time.Now()
+			continue
+		}
+		if !pending || time.Since(changedAt) < w.opts.debounce {
+			continue
+		}
+		pending = false
+		w.reload()
+		last = w.tracker.snapshot(w.conf)
+	}
+}
+
+func (w *watcher) reload() {
+	obj := reflect.New(w.objType).Interface()
+	ft, err := w.conf.parseTracked(w.confName, obj)
+	if err != nil {
+		// 解析失败时，可能还没读取到所有的文件，所以合并新老文件列表，以继续监听
+		w.tracker.merge(ft)
+		w.onChange(nil, err)
+		return
+	}
+	w.tracker = ft
+	w.onChange(obj, nil)
+}
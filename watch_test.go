@@ -0,0 +1,96 @@
+// Copyright(C) 2026 github.com/fsgo  All Rights Reserved.
+// Author: hidu <duv123@gmail.com>
+// Date: 2026/10/18
+
+package fsconf
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fsgo/fst"
+)
+
+func TestConfigure_Watch(t *testing.T) {
+	dir := t.TempDir()
+	mainFile := filepath.Join(dir, "app.json")
+	subFile := filepath.Join(dir, "sub", "a.json")
+	fst.NoError(t, os.MkdirAll(filepath.Dir(subFile), 0755))
+	fst.NoError(t, os.WriteFile(subFile, []byte(`"B":"b1"`), 0644))
+	fst.NoError(t, os.WriteFile(mainFile, []byte("# hook.template  Enable=true\n{\"A\":\"a1\",\n{{ include \"sub/*.json\" }}\n}"), 0644))
+
+	type cfg struct {
+		A string
+		B string
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	type event struct {
+		obj any
+		err error
+	}
+	events := make(chan event, 10)
+
+	var c1 cfg
+	err := NewDefault().Watch(ctx, mainFile, &c1, func(obj any, err error) {
+		events <- event{obj: obj, err: err}
+	}, WatchInterval(10*time.Millisecond), WatchDebounce(20*time.Millisecond))
+	fst.NoError(t, err)
+	fst.Equal(t, cfg{A: "a1", B: "b1"}, c1)
+
+	waitEvent := func() event {
+		select {
+		case e := <-events:
+			return e
+		case <-time.After(3 * time.Second):
+			t.Fatal("wait onChange timeout")
+		}
+		return event{}
+	}
+
+	t.Run("included file changed", func(t *testing.T) {
+		fst.NoError(t, os.WriteFile(subFile, []byte(`"B":"b2-new"`), 0644))
+		e := waitEvent()
+		fst.NoError(t, e.err)
+		fst.Equal[any](t, &cfg{A: "a1", B: "b2-new"}, e.obj)
+		fst.Equal(t, cfg{A: "a1", B: "b1"}, c1)
+	})
+
+	t.Run("main file broken", func(t *testing.T) {
+		fst.NoError(t, os.WriteFile(mainFile, []byte(`{"A":`), 0644))
+		e := waitEvent()
+		fst.Error(t, e.err)
+		fst.Nil(t, e.obj)
+	})
+
+	t.Run("main file fixed", func(t *testing.T) {
+		fst.NoError(t, os.WriteFile(mainFile, []byte(`{"A":"a3","B":"b3"}`), 0644))
+		e := waitEvent()
+		fst.NoError(t, e.err)
+		fst.Equal[any](t, &cfg{A: "a3", B: "b3"}, e.obj)
+	})
+
+	t.Run("stopped by context", func(t *testing.T) {
+		cancel()
+		time.Sleep(50 * time.Millisecond)
+		fst.NoError(t, os.WriteFile(mainFile, []byte(`{"A":"a4-stopped"}`), 0644))
+		select {
+		case e := <-events:
+			t.Fatalf("unexpected event: %v", e)
+		case <-time.After(200 * time.Millisecond):
+		}
+	})
+}
+
+func TestConfigure_Watch_error(t *testing.T) {
+	ctx := context.Background()
+	var c1 map[string]string
+	fst.Error(t, Watch(ctx, "not_exists.json", &c1, func(obj any, err error) {}))
+	fst.Error(t, Watch(ctx, "abc.json", c1, func(obj any, err error) {}))
+	fst.Error(t, Watch(ctx, "abc.json", &c1, nil))
+}